@@ -0,0 +1,169 @@
+package hive
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// Errors returned when decoding the binary encoding of a game
+var (
+	ErrInvalidEncoding    = errors.New("invalid binary encoding")
+	ErrUnsupportedVersion = errors.New("unsupported binary encoding version")
+)
+
+// binaryVersion is the version of the binary encoding written by MarshalBinary
+const binaryVersion = 1
+
+// Flags stored in the binary encoding
+const (
+	binaryNoQueenFirstTurn = 1 << iota
+	binaryLastMoved
+)
+
+// maxBinaryValue bounds decoded numbers so they fit in an int on every platform
+const maxBinaryValue = 1<<31 - 1
+
+// MarshalBinary encodes the position of a Game compactly.
+// The encoding is a version byte followed by unsigned varints:
+//   - flags for the tournament rule and whether there is a last moved piece
+//   - the number of expansions followed by each expansion creature
+//   - the colour to move and the number of moves played
+//   - the number of occupied hexes followed by, for each one, its coordinates, its height and each
+//     piece from the bottom as its creature and then its number times MaxPlayers plus its colour
+//   - the coordinates of the last moved piece, if there is one
+//
+// Coordinates are stored as q and r relative to the smallest q and r on the board, so positions that
+// differ only by where they sit on the grid have the same encoding. Reserves and history are not stored.
+func (g Game) MarshalBinary() ([]byte, error) {
+	occupied := g.Occupied()
+	var minQ, minR int
+	for i, h := range occupied {
+		if i == 0 || h.Q() < minQ {
+			minQ = h.Q()
+		}
+		if i == 0 || h.R() < minR {
+			minR = h.R()
+		}
+	}
+
+	var flags int
+	if g.options.NoQueenFirstTurn {
+		flags |= binaryNoQueenFirstTurn
+	}
+	lastMoved, hasLastMoved := g.LastMoved()
+	if hasLastMoved {
+		flags |= binaryLastMoved
+	}
+
+	data := []byte{binaryVersion}
+	var buf [binary.MaxVarintLen64]byte
+	put := func(values ...int) {
+		for _, v := range values {
+			n := binary.PutUvarint(buf[:], uint64(v))
+			data = append(data, buf[:n]...)
+		}
+	}
+	put(int(flags), len(g.options.Expansions))
+	put(g.options.Expansions...)
+	put(g.toMove, g.moveCount, len(occupied))
+	for _, h := range occupied {
+		stack := g.positions[h]
+		put(h.Q()-minQ, h.R()-minR, len(stack))
+		for _, p := range stack {
+			put(p.creature, p.number*MaxPlayers+p.colour)
+		}
+	}
+	if hasLastMoved {
+		put(lastMoved.Q()-minQ, lastMoved.R()-minR)
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a Game encoded by MarshalBinary, validating the position as a Builder does
+func (g *Game) UnmarshalBinary(data []byte) error {
+	if len(data) == 0 {
+		return fmt.Errorf("%w: no data", ErrInvalidEncoding)
+	}
+	if data[0] != binaryVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, data[0])
+	}
+	data = data[1:]
+
+	var err error
+	get := func() int {
+		if err != nil {
+			return 0
+		}
+		v, n := binary.Uvarint(data)
+		switch {
+		case n <= 0:
+			err = fmt.Errorf("%w: truncated or overlong value", ErrInvalidEncoding)
+			return 0
+		case v > maxBinaryValue:
+			err = fmt.Errorf("%w: value %d out of range", ErrInvalidEncoding, v)
+			return 0
+		}
+		data = data[n:]
+		return int(v)
+	}
+	getHex := func() hexgrid.Hex {
+		q, r := get(), get()
+		return hexgrid.MustNew(q, r, -q-r)
+	}
+
+	var opts Options
+	flags := get()
+	if flags&^(binaryNoQueenFirstTurn|binaryLastMoved) != 0 {
+		return fmt.Errorf("%w: unknown flags %b", ErrInvalidEncoding, flags)
+	}
+	opts.NoQueenFirstTurn = flags&binaryNoQueenFirstTurn != 0
+	// Each value takes at least one byte, so counts larger than the remaining data are invalid
+	expansions := get()
+	if expansions > len(data) {
+		return fmt.Errorf("%w: %d expansions", ErrInvalidEncoding, expansions)
+	}
+	for i := 0; i < expansions && err == nil; i++ {
+		opts.Expansions = append(opts.Expansions, get())
+	}
+
+	b := NewBuilder(opts).SetToMove(get()).SetMoveCount(get())
+	stacks := get()
+	seen := map[hexgrid.Hex]struct{}{}
+	for i := 0; i < stacks && err == nil; i++ {
+		h := getHex()
+		if _, ok := seen[h]; ok {
+			return fmt.Errorf("%w: hex %v encoded more than once", ErrInvalidEncoding, h)
+		}
+		seen[h] = struct{}{}
+		height := get()
+		if err == nil && height == 0 {
+			return fmt.Errorf("%w: empty stack", ErrInvalidEncoding)
+		}
+		for j := 0; j < height && err == nil; j++ {
+			creature, v := get(), get()
+			if err == nil && v < MaxPlayers {
+				return fmt.Errorf("%w: piece without a number", ErrInvalidEncoding)
+			}
+			b.Add(h, Piece{creature: creature, colour: v % MaxPlayers, number: v / MaxPlayers})
+		}
+	}
+	if flags&binaryLastMoved != 0 {
+		b.SetLastMoved(getHex())
+	}
+	if err != nil {
+		return err
+	}
+	if len(data) > 0 {
+		return fmt.Errorf("%w: %d trailing bytes", ErrInvalidEncoding, len(data))
+	}
+
+	built, err := b.Build()
+	if err != nil {
+		return err
+	}
+	*g = *built
+	return nil
+}
@@ -0,0 +1,209 @@
+package hive
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// binaryTestGames returns games covering stacks, expansions and the tournament rule
+func binaryTestGames(t testing.TB) map[string]*Game {
+	games := map[string]*Game{
+		"Empty":      mustNewGame(t, Options{NoQueenFirstTurn: true}),
+		"Expansions": mustNewGame(t, Options{Expansions: []int{Mosquito, Ladybug, Pillbug}}),
+	}
+	for i, m := range historyTestMoves {
+		if err := games["Expansions"].Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	return games
+}
+
+func TestMarshalBinary(t *testing.T) {
+	g, err := NewBuilder(Options{}).
+		Add(hexgrid.MustNew(0, 0, 0), NewPiece(QueenBee, White, 1)).
+		SetToMove(Black).
+		SetMoveCount(1).
+		SetLastMoved(hexgrid.MustNew(0, 0, 0)).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	data, err := g.MarshalBinary()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := []byte{
+		binaryVersion,
+		binaryLastMoved, 0, // flags and expansions
+		Black, 1, // to move and move count
+		1, 0, 0, 1, QueenBee, 1*MaxPlayers + White, // one stack holding wQ
+		0, 0, // last moved
+	}
+	if !bytes.Equal(data, want) {
+		t.Errorf("Got %v, want %v", data, want)
+	}
+}
+
+func TestBinaryRoundTrip(t *testing.T) {
+	for name, g := range binaryTestGames(t) {
+		t.Run(name, func(t *testing.T) {
+			data, err := g.MarshalBinary()
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			var got Game
+			if err := got.UnmarshalBinary(data); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+
+			// The decoded position is the original moved so its smallest q and r are zero
+			var minQ, minR int
+			for i, h := range g.Occupied() {
+				if i == 0 || h.Q() < minQ {
+					minQ = h.Q()
+				}
+				if i == 0 || h.R() < minR {
+					minR = h.R()
+				}
+			}
+			translate := func(h hexgrid.Hex) hexgrid.Hex {
+				return hexgrid.MustNew(h.Q()-minQ, h.R()-minR, h.S()+minQ+minR)
+			}
+			want := g.Copy()
+			want.positions = map[hexgrid.Hex][]Piece{}
+			for h, stack := range g.positions {
+				want.positions[translate(h)] = stack
+			}
+			if want.hasMoved {
+				want.lastMoved = translate(g.lastMoved)
+			}
+			if !sameState(&got, &want) {
+				t.Errorf("Got %s, want %s", FormatPosition(&got), FormatPosition(&want))
+			}
+			if !reflect.DeepEqual(got.Options(), g.Options()) {
+				t.Errorf("Got options %+v, want %+v", got.Options(), g.Options())
+			}
+		})
+	}
+}
+
+func TestBinaryTranslation(t *testing.T) {
+	var encodings [][]byte
+	for _, offset := range []hexgrid.Hex{hexgrid.MustNew(0, 0, 0), hexgrid.MustNew(3, -5, 2)} {
+		g, err := NewBuilder(Options{}).
+			Add(hexgrid.MustNew(offset.Q(), offset.R(), offset.S()), NewPiece(QueenBee, White, 1)).
+			Add(hexgrid.MustNew(offset.Q()+1, offset.R()-1, offset.S()), NewPiece(QueenBee, Black, 1)).
+			SetMoveCount(2).
+			Build()
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		data, err := g.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		encodings = append(encodings, data)
+	}
+	if !bytes.Equal(encodings[0], encodings[1]) {
+		t.Errorf("Translated positions encoded differently: %v and %v", encodings[0], encodings[1])
+	}
+}
+
+func TestUnmarshalBinaryErrors(t *testing.T) {
+	tests := map[string]struct {
+		data []byte
+		err  error
+	}{
+		"Empty": {
+			data: nil,
+			err:  ErrInvalidEncoding,
+		},
+		"Unknown version": {
+			data: []byte{2, 0, 0, White, 0, 0},
+			err:  ErrUnsupportedVersion,
+		},
+		"Truncated": {
+			data: []byte{binaryVersion, 0, 0, White, 0, 1, 0, 0},
+			err:  ErrInvalidEncoding,
+		},
+		"Trailing bytes": {
+			data: []byte{binaryVersion, 0, 0, White, 0, 0, 0},
+			err:  ErrInvalidEncoding,
+		},
+		"Unknown flags": {
+			data: []byte{binaryVersion, 4, 0, White, 0, 0},
+			err:  ErrInvalidEncoding,
+		},
+		"Value out of range": {
+			data: []byte{binaryVersion, 0, 0, White, 0xff, 0xff, 0xff, 0xff, 0x7f, 0},
+			err:  ErrInvalidEncoding,
+		},
+		"Empty stack": {
+			data: []byte{binaryVersion, 0, 0, White, 0, 1, 0, 0, 0},
+			err:  ErrInvalidEncoding,
+		},
+		"Piece without number": {
+			data: []byte{binaryVersion, 0, 0, White, 0, 1, 0, 0, 1, QueenBee, White},
+			err:  ErrInvalidEncoding,
+		},
+		"Repeated hex": {
+			data: []byte{binaryVersion, 0, 0, White, 0, 2, 0, 0, 1, QueenBee, 3, 0, 0, 1, QueenBee, 2},
+			err:  ErrInvalidEncoding,
+		},
+		"Unknown creature": {
+			data: []byte{binaryVersion, 0, 0, White, 0, 1, 0, 0, 1, 99, 3},
+			err:  ErrUnknownCreature,
+		},
+		"Too many pieces": {
+			data: []byte{binaryVersion, 0, 0, White, 0, 1, 0, 0, 2, QueenBee, 3, QueenBee, 3},
+			err:  ErrInvalidPosition,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var g Game
+			if err := g.UnmarshalBinary(test.data); !errors.Is(err, test.err) {
+				t.Errorf("Got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func FuzzUnmarshalBinary(f *testing.F) {
+	for _, g := range binaryTestGames(f) {
+		data, err := g.MarshalBinary()
+		if err != nil {
+			f.Fatalf("Unexpected error %v", err)
+		}
+		f.Add(data)
+	}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		var g Game
+		if err := g.UnmarshalBinary(data); err != nil {
+			return
+		}
+		// Any position that decodes must encode to a canonical form that decodes to the same position
+		encoded, err := g.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Cannot encode decoded game: %v", err)
+		}
+		var again Game
+		if err := again.UnmarshalBinary(encoded); err != nil {
+			t.Fatalf("Cannot decode %v: %v", encoded, err)
+		}
+		reencoded, err := again.MarshalBinary()
+		if err != nil {
+			t.Fatalf("Cannot encode decoded game: %v", err)
+		}
+		if !bytes.Equal(encoded, reencoded) {
+			t.Errorf("Encoding is not stable: %v then %v", encoded, reencoded)
+		}
+	})
+}
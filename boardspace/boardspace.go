@@ -0,0 +1,434 @@
+// Package boardspace imports games from the SGF based text records archived by Boardspace.net
+package boardspace
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// Errors returned when importing games
+var (
+	ErrInvalidSGF  = errors.New("invalid SGF")
+	ErrUnsupported = errors.New("unsupported feature")
+)
+
+// directionMarkers are the characters placing a piece next to a reference piece in a position
+const directionMarkers = `/-\`
+
+// hiveGameNumber is the value of the GM property for Hive games
+const hiveGameNumber = "27"
+
+// TagResigned is the record tag naming the colour that resigned, if any
+const TagResigned = "Resigned"
+
+// playerColours are the colours played by each player property.
+// Boardspace's first player always has the white pieces.
+var playerColours = map[string]int{
+	"P0": hive.White,
+	"P1": hive.Black,
+}
+
+// colourNames are the names of colours used in record tags
+var colourNames = map[int]string{
+	hive.Black: "Black",
+	hive.White: "White",
+}
+
+// ImportError reports the line of an archive that could not be imported
+type ImportError struct {
+	Line int
+	Err  error
+}
+
+func (e *ImportError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *ImportError) Unwrap() error {
+	return e.Err
+}
+
+// Game is one game read from an archive
+type Game struct {
+	Line   int          // Line the game starts on
+	Record *hive.Record // Imported game, nil if it could not be imported
+	Err    error        // Reason the game could not be imported
+}
+
+// property is a single SGF property such as P0[1 dropb wQ N 13 .]
+type property struct {
+	ident string
+	value string
+	line  int
+}
+
+// gameTree is the sequence of nodes in a single SGF game along with whether it contained variations
+type gameTree struct {
+	line       int
+	nodes      [][]property
+	variations bool
+}
+
+// ReadAll imports every game in an archive.
+// A game that cannot be imported is returned with an error saying why, so the rest of the archive can still be used.
+// An error is only returned if the archive is not valid SGF.
+func ReadAll(r io.Reader) ([]Game, error) {
+	trees, err := parseSGF(r)
+	if err != nil {
+		return nil, err
+	}
+	games := make([]Game, len(trees))
+	for i, tree := range trees {
+		games[i].Line = tree.line
+		games[i].Record, games[i].Err = importGame(tree)
+	}
+	return games, nil
+}
+
+// Read imports a single game, such as a file downloaded from Boardspace
+func Read(r io.Reader) (*hive.Record, error) {
+	trees, err := parseSGF(r)
+	if err != nil {
+		return nil, err
+	}
+	if len(trees) != 1 {
+		return nil, fmt.Errorf("%w: found %d games, want 1", ErrInvalidSGF, len(trees))
+	}
+	return importGame(trees[0])
+}
+
+// importGame replays the moves of a game tree
+func importGame(tree gameTree) (*hive.Record, error) {
+	fail := func(line int, format string, a ...interface{}) (*hive.Record, error) {
+		return nil, &ImportError{Line: line, Err: fmt.Errorf(format, a...)}
+	}
+	if tree.variations {
+		return fail(tree.line, "%w: variations", ErrUnsupported)
+	}
+
+	rec := &hive.Record{Tags: map[string]string{}}
+	var opts hive.Options
+	var moves []property
+	for _, node := range tree.nodes {
+		for _, prop := range node {
+			switch prop.ident {
+			case "GM":
+				if prop.value != hiveGameNumber {
+					return fail(prop.line, "%w: game %s is not Hive", ErrUnsupported, prop.value)
+				}
+			case "SU":
+				var err error
+				if opts, err = parseVariant(prop.value); err != nil {
+					return fail(prop.line, "%w", err)
+				}
+			case "DT":
+				rec.Tags["Date"] = prop.value
+			case "GN":
+				rec.Tags["Event"] = prop.value
+			case "P0", "P1":
+				fields := strings.Fields(prop.value)
+				switch {
+				case len(fields) == 0:
+					return fail(prop.line, "%w: empty %s property", ErrInvalidSGF, prop.ident)
+				case fields[0] == "id":
+					name, err := strconv.Unquote(strings.TrimSpace(strings.TrimPrefix(prop.value, "id")))
+					if err != nil {
+						return fail(prop.line, "%w: player name %q is not quoted", ErrInvalidSGF, prop.value)
+					}
+					rec.Tags[colourNames[playerColours[prop.ident]]] = name
+				case fields[0] == "time":
+					// Clock times are not recorded
+				default:
+					moves = append(moves, prop)
+				}
+			}
+		}
+	}
+
+	g, err := hive.NewGame(opts)
+	if err != nil {
+		return fail(tree.line, "%w", err)
+	}
+	rec.Game = g
+
+	im := importer{game: g}
+	for _, prop := range moves {
+		resigned, err := im.play(prop)
+		if err != nil {
+			return fail(prop.line, "%w", err)
+		}
+		if resigned {
+			rec.Tags[TagResigned] = colourNames[playerColours[prop.ident]]
+		}
+	}
+	return rec, nil
+}
+
+// parseVariant returns the options for a Boardspace variant such as hive-lmp
+func parseVariant(s string) (hive.Options, error) {
+	var opts hive.Options
+	name := strings.ToLower(s)
+	if name == "hive" {
+		return opts, nil
+	}
+	if !strings.HasPrefix(name, "hive-") {
+		return opts, fmt.Errorf("%w: variant %q", ErrUnsupported, s)
+	}
+	gameType := hive.BaseGameType + "+" + strings.ToUpper(strings.TrimPrefix(name, "hive-"))
+	opts, err := hive.ParseGameType(gameType)
+	if err != nil {
+		return opts, fmt.Errorf("%w: variant %q", ErrUnsupported, s)
+	}
+	return opts, nil
+}
+
+// importer converts Boardspace moves to moves in a game
+type importer struct {
+	game *hive.Game
+}
+
+// play plays a single move such as "1 dropb wQ N 13 .", returning true if it was a resignation
+func (im *importer) play(prop property) (bool, error) {
+	fields := strings.Fields(prop.value)
+	// Moves are usually numbered
+	if _, err := strconv.Atoi(fields[0]); err == nil {
+		fields = fields[1:]
+	}
+	if len(fields) == 0 {
+		return false, fmt.Errorf("%w: empty move", ErrInvalidSGF)
+	}
+
+	verb, args := strings.ToLower(fields[0]), fields[1:]
+	switch verb {
+	case "start", "done", "pick", "pickb", "reset", "offerdraw", "declinedraw":
+		// Selections and confirmations made in the user interface do not change the position
+		return false, nil
+	case "pass":
+		return false, im.game.Play(hive.Move{Kind: hive.Pass})
+	case "resign":
+		return true, nil
+	case "dropb", "pdropb":
+		return false, im.placeOrMove(args)
+	case "move", "pmove":
+		// Moves made on the board name the player making them first, as in "move W wA1 N 14 wQ/"
+		if len(args) > 0 && (strings.EqualFold(args[0], "W") || strings.EqualFold(args[0], "B")) {
+			args = args[1:]
+		}
+		return false, im.placeOrMove(args)
+	}
+	return false, fmt.Errorf("%w: %q", ErrUnsupported, fields[0])
+}
+
+// placeOrMove plays a piece given as piece, column, row and position, such as "wQ N 13 -wG1".
+// Boardspace writes the position relative to a piece on the board in the notation UHP is based on,
+// so it is used to place the piece rather than the column and row, which only need to be well formed.
+func (im *importer) placeOrMove(args []string) error {
+	if len(args) < 3 {
+		return fmt.Errorf("%w: move needs a piece, column and row", ErrInvalidSGF)
+	}
+	piece, err := parsePiece(args[0])
+	if err != nil {
+		return err
+	}
+	if len(args[1]) != 1 || args[1][0] < 'A' || args[1][0] > 'Z' {
+		return fmt.Errorf("%w: column %q", ErrInvalidSGF, args[1])
+	}
+	if _, err := strconv.Atoi(args[2]); err != nil {
+		return fmt.Errorf("%w: row %q", ErrInvalidSGF, args[2])
+	}
+
+	// The first piece is placed on its own and has no position
+	if len(im.game.Occupied()) == 0 {
+		return im.game.Play(hive.Move{Kind: hive.Placement, Creature: piece.Creature(), To: hexgrid.MustNew(0, 0, 0)})
+	}
+	if len(args) < 4 || args[3] == "." {
+		return fmt.Errorf("%w: move of %s has no position", ErrInvalidSGF, args[0])
+	}
+	position, err := parsePosition(args[3])
+	if err != nil {
+		return err
+	}
+	m, err := im.game.ParseMove(hive.FormatPiece(piece) + " " + position)
+	if err != nil {
+		return err
+	}
+	// A piece picked up and dropped where it was is not a move
+	if m.Kind == hive.Movement && m.From == m.To {
+		return nil
+	}
+	return im.game.Play(m)
+}
+
+// parsePosition returns a Boardspace position such as \wQ1 with its reference piece named as in the hive package
+func parsePosition(s string) (string, error) {
+	prefix, suffix := "", ""
+	if len(s) > 0 && strings.IndexByte(directionMarkers, s[0]) >= 0 {
+		prefix, s = s[:1], s[1:]
+	}
+	if len(s) > 0 && strings.IndexByte(directionMarkers, s[len(s)-1]) >= 0 {
+		s, suffix = s[:len(s)-1], s[len(s)-1:]
+	}
+	ref, err := parsePiece(s)
+	if err != nil {
+		return "", err
+	}
+	return prefix + hive.FormatPiece(ref) + suffix, nil
+}
+
+// parsePiece returns the piece for a Boardspace piece name.
+// Boardspace sometimes numbers creatures with a single piece, such as wQ1.
+func parsePiece(s string) (hive.Piece, error) {
+	piece, err := hive.ParsePiece(s)
+	if err != nil && strings.HasSuffix(s, "1") {
+		if p, err2 := hive.ParsePiece(strings.TrimSuffix(s, "1")); err2 == nil {
+			return p, nil
+		}
+	}
+	return piece, err
+}
+
+// parseSGF reads the game trees of an SGF collection
+func parseSGF(r io.Reader) ([]gameTree, error) {
+	p := &sgfParser{reader: bufio.NewReader(r), line: 1}
+	var trees []gameTree
+	for {
+		c, err := p.next()
+		if err == io.EOF {
+			return trees, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if c != '(' {
+			return nil, p.errorf("expected '(' but found %q", c)
+		}
+		tree := gameTree{line: p.line}
+		if err := p.parseTree(&tree); err != nil {
+			return nil, err
+		}
+		trees = append(trees, tree)
+	}
+}
+
+// sgfParser reads SGF one character at a time, keeping track of the line
+type sgfParser struct {
+	reader *bufio.Reader
+	line   int
+}
+
+func (p *sgfParser) errorf(format string, a ...interface{}) error {
+	return &ImportError{Line: p.line, Err: fmt.Errorf("%w: "+format, append([]interface{}{ErrInvalidSGF}, a...)...)}
+}
+
+// read returns the next character
+func (p *sgfParser) read() (byte, error) {
+	c, err := p.reader.ReadByte()
+	if c == '\n' {
+		p.line++
+	}
+	return c, err
+}
+
+// next returns the next character that is not white space
+func (p *sgfParser) next() (byte, error) {
+	for {
+		c, err := p.read()
+		if err != nil || !strings.ContainsRune(" \t\r\n", rune(c)) {
+			return c, err
+		}
+	}
+}
+
+// parseTree reads the rest of a game tree after its opening parenthesis.
+// Nodes of variations are read but only those of the main line are kept.
+func (p *sgfParser) parseTree(tree *gameTree) error {
+	for {
+		c, err := p.next()
+		if err == io.EOF {
+			return p.errorf("unterminated game tree")
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case c == ')':
+			return nil
+		case c == '(':
+			tree.variations = true
+			if err := p.parseTree(&gameTree{}); err != nil {
+				return err
+			}
+		case c == ';':
+			tree.nodes = append(tree.nodes, nil)
+		case c >= 'A' && c <= 'Z':
+			if len(tree.nodes) == 0 {
+				return p.errorf("property outside a node")
+			}
+			props, err := p.parseProperty(c)
+			if err != nil {
+				return err
+			}
+			last := len(tree.nodes) - 1
+			tree.nodes[last] = append(tree.nodes[last], props...)
+		default:
+			return p.errorf("unexpected %q", c)
+		}
+	}
+}
+
+// parseProperty reads a property identifier starting with c and its values
+func (p *sgfParser) parseProperty(c byte) ([]property, error) {
+	ident := []byte{c}
+	for {
+		c, err := p.read()
+		if err != nil {
+			return nil, p.errorf("unterminated property")
+		}
+		if c == '[' {
+			break
+		}
+		if !(c >= 'A' && c <= 'Z' || c >= '0' && c <= '9') {
+			return nil, p.errorf("invalid property name %q", string(append(ident, c)))
+		}
+		ident = append(ident, c)
+	}
+
+	var props []property
+	for {
+		line := p.line
+		var value []byte
+		for {
+			c, err := p.read()
+			if err != nil {
+				return nil, p.errorf("unterminated value of property %s", ident)
+			}
+			if c == ']' {
+				break
+			}
+			if c == '\\' {
+				if c, err = p.read(); err != nil {
+					return nil, p.errorf("unterminated value of property %s", ident)
+				}
+			}
+			value = append(value, c)
+		}
+		props = append(props, property{ident: string(ident), value: string(value), line: line})
+
+		// A property may have several values
+		c, err := p.next()
+		if err != nil {
+			return props, nil
+		}
+		if c != '[' {
+			return props, p.reader.UnreadByte()
+		}
+	}
+}
@@ -0,0 +1,223 @@
+package boardspace
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+//     __    __
+//  __/wG\__/bP\
+// /wQ\__/bQ\__/
+// \__/  \__/
+
+// sampleGame is a short game with the expansion pieces, ending in Black resigning with the position above
+const sampleGame = `(;
+GM[27]VV[1]
+SU[hive-lmp]
+P0[id "alice"]
+P1[id "bob"]
+DT[Jan 02 2024]
+; P0[0 Start P0]
+; P0[1 dropb wG1 N 13 .]
+; P0[2 done]
+; P1[3 dropb bQ O 13 wG1\\]
+; P1[4 done]
+; P0[5 dropb wQ M 13 \\wG1]
+; P0[6 done]
+; P1[7 dropb bP P 13 bQ-]
+; P1[8 done]
+; P0[9 pickb M 13 wQ]
+; P0[10 dropb wQ M 12 -wG1]
+; P0[11 done]
+; P1[12 resign]
+)
+`
+
+// sampleRecord is sampleGame in the record format of the hive package
+const sampleRecord = `[Date "Jan 02 2024"]
+[White "alice"]
+[Black "bob"]
+[GameType "Base+MLP"]
+[Result "InProgress"]
+[Resigned "Black"]
+
+1. wG1
+2. bQ wG1\
+3. wQ \wG1
+4. bP bQ-
+5. wQ -wG1
+`
+
+//     __
+//    /wS\
+//    \__/
+//  __/wP\__
+// /wQ\__/bA\
+// \__/  \__/
+// /bQ\
+// \__/
+// /bS\
+// \__/
+
+// movesGame is an unfinished game with pieces moved on the board, ending with the Pillbug throwing the Black Ant
+// to reach the position above
+const movesGame = `(;
+GM[27]SU[hive-p]
+; P0[1 dropb wQ N 13 .]
+; P1[2 dropb bQ N 12 /wQ]
+; P0[3 dropb wP N 14 wQ/]
+; P1[4 dropb bA1 O 12 bQ\\]
+; P0[5 move W wP O 14 wQ-]
+; P1[6 move B bA1 O 13 /wP]
+; P0[7 dropb wS1 O 15 wP/]
+; P1[8 dropb bS1 N 11 /bQ]
+; P0[9 pmove W bA1 P 14 wP\\]
+)
+`
+
+// movesRecord is movesGame in the record format of the hive package
+const movesRecord = `[GameType "Base+P"]
+[Result "InProgress"]
+
+1. wQ
+2. bQ /wQ
+3. wP wQ/
+4. bA1 bQ\
+5. wP wQ-
+6. bA1 /wP
+7. wS1 wP/
+8. bS1 /bQ
+9. bA1 wP\
+`
+
+func TestRead(t *testing.T) {
+	tests := map[string]struct {
+		sgf    string
+		record string
+	}{
+		"Placements":  {sgf: sampleGame, record: sampleRecord},
+		"Board moves": {sgf: movesGame, record: movesRecord},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			rec, err := Read(strings.NewReader(test.sgf))
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			var buf bytes.Buffer
+			if err := hive.WriteRecord(&buf, *rec); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if got := buf.String(); got != test.record {
+				t.Errorf("Got\n%s\nwant\n%s", got, test.record)
+			}
+		})
+	}
+
+	// The pmove is played as a throw by the Pillbug
+	rec, err := Read(strings.NewReader(movesGame))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	history := rec.Game.History()
+	if last := history[len(history)-1].Move; !last.Ability || last.Via != hexgrid.MustNew(1, -1, 0) {
+		t.Errorf("Got last move %+v, want a throw by the Pillbug", last)
+	}
+}
+
+func TestReadAll(t *testing.T) {
+	archive := sampleGame +
+		"(;GM[27]SU[hive-ultimate];P0[1 dropb wQ N 13 .])\n" +
+		"(;GM[27]SU[hive];P0[1 dropb wQ N 13 .](;P1[2 dropb bQ O 13 wQ-])(;P1[2 dropb bQ M 13 -wQ]))\n" +
+		"(;GM[27]SU[hive]\n;P0[1 dropb wQ N 13 .]\n;P1[2 swap])\n" +
+		"(;GM[1];B[pd])\n" +
+		"(;GM[27]SU[hive]\n;P0[1 dropb wQ N 13 .]\n;P1[2 dropb bQ N 13 wQ])\n" +
+		"(;GM[27]SU[hive]\n;P0[1 dropb wQ N 13 .]\n;P1[2 dropb bS1 $ 13 .])\n"
+
+	tests := []struct {
+		line int
+		err  error
+	}{
+		{line: 1},
+		{line: 21, err: ErrUnsupported},
+		{line: 22, err: ErrUnsupported},
+		{line: 25, err: ErrUnsupported},
+		{line: 26, err: ErrUnsupported},
+		{line: 29, err: hive.ErrIllegalMove},
+		{line: 32, err: ErrInvalidSGF},
+	}
+
+	games, err := ReadAll(strings.NewReader(archive))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if len(games) != len(tests) {
+		t.Fatalf("Got %d games, want %d", len(games), len(tests))
+	}
+	for i, test := range tests {
+		game := games[i]
+		if !errors.Is(game.Err, test.err) {
+			t.Errorf("Game %d: got error %v, want %v", i+1, game.Err, test.err)
+		}
+		if (game.Record == nil) != (test.err != nil) {
+			t.Errorf("Game %d: got record %v with error %v", i+1, game.Record, game.Err)
+		}
+		var importErr *ImportError
+		if test.err != nil && !errors.As(game.Err, &importErr) {
+			t.Errorf("Game %d: got error %v, want an ImportError", i+1, game.Err)
+		}
+		if importErr != nil && importErr.Line != test.line {
+			t.Errorf("Game %d: got error on line %d, want %d", i+1, importErr.Line, test.line)
+		}
+	}
+}
+
+func TestReadInvalidSGF(t *testing.T) {
+	tests := map[string]struct {
+		sgf  string
+		line int
+	}{
+		"Missing tree": {
+			sgf:  ";GM[27]",
+			line: 1,
+		},
+		"Unterminated tree": {
+			sgf:  "(;GM[27]\n;P0[1 dropb wQ N 13 .]\n",
+			line: 3,
+		},
+		"Unterminated value": {
+			sgf:  "(;GM[27]\n;P0[1 dropb wQ N 13 .)\n",
+			line: 3,
+		},
+		"Property outside node": {
+			sgf:  "(GM[27])",
+			line: 1,
+		},
+		"Invalid property name": {
+			sgf:  "(;\nGm[27])",
+			line: 2,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Read(strings.NewReader(test.sgf))
+			if !errors.Is(err, ErrInvalidSGF) {
+				t.Fatalf("Got error %v, want %v", err, ErrInvalidSGF)
+			}
+			var importErr *ImportError
+			if !errors.As(err, &importErr) {
+				t.Fatalf("Got error %v, want an ImportError", err)
+			}
+			if importErr.Line != test.line {
+				t.Errorf("Got error on line %d, want %d", importErr.Line, test.line)
+			}
+		})
+	}
+}
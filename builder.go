@@ -0,0 +1,139 @@
+package hive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// ErrInvalidPosition is returned when a position cannot occur in a game
+var ErrInvalidPosition = errors.New("invalid position")
+
+// climbers are the creatures that can be above another piece in a stack.
+// A Mosquito climbs by copying a Beetle.
+var climbers = map[int]bool{Beetle: true, Mosquito: true}
+
+// placedPiece is a piece added to a Builder
+type placedPiece struct {
+	h     hexgrid.Hex
+	piece Piece
+}
+
+// Builder sets up a game position piece by piece
+type Builder struct {
+	options   Options
+	pieces    []placedPiece
+	toMove    int
+	moveCount int
+	lastMoved *hexgrid.Hex
+}
+
+// NewBuilder returns a Builder for an empty board with White to move
+func NewBuilder(opts Options) *Builder {
+	return &Builder{options: opts, toMove: White}
+}
+
+// Add places a piece on top of the stack on a hex.
+// Pieces with a number of zero are numbered when the game is built.
+func (b *Builder) Add(h hexgrid.Hex, p Piece) *Builder {
+	b.pieces = append(b.pieces, placedPiece{h: h, piece: p})
+	return b
+}
+
+// SetToMove sets the colour of the player whose turn it is
+func (b *Builder) SetToMove(colour int) *Builder {
+	b.toMove = colour
+	return b
+}
+
+// SetMoveCount sets the number of moves played so far
+func (b *Builder) SetMoveCount(moveCount int) *Builder {
+	b.moveCount = moveCount
+	return b
+}
+
+// SetLastMoved sets the hex of the piece played on the previous turn
+func (b *Builder) SetLastMoved(h hexgrid.Hex) *Builder {
+	b.lastMoved = &h
+	return b
+}
+
+// Build validates the position and returns it as a game.
+// Only climbing creatures may be above another piece and the player to move must follow from the move count.
+// Each player's reserve holds their starting pieces less those on the board.
+func (b *Builder) Build() (*Game, error) {
+	g, err := NewGame(b.options)
+	if err != nil {
+		return nil, err
+	}
+	if b.toMove < 0 || b.toMove >= MaxPlayers {
+		return nil, fmt.Errorf("%w: unknown colour %d to move", ErrInvalidPosition, b.toMove)
+	}
+	if b.moveCount < 0 {
+		return nil, fmt.Errorf("%w: negative move count %d", ErrInvalidPosition, b.moveCount)
+	}
+	// White moves first and the players alternate, passing included
+	if want := (White + b.moveCount) % MaxPlayers; b.toMove != want {
+		return nil, fmt.Errorf("%w: colour %d to move after %d moves", ErrInvalidPosition, b.toMove, b.moveCount)
+	}
+	g.toMove = b.toMove
+	g.moveCount = b.moveCount
+
+	// Collect numbered pieces first so unnumbered pieces cannot take their numbers
+	used := map[Piece]struct{}{}
+	for _, pp := range b.pieces {
+		p := pp.piece
+		ct, ok := GetCreatureType(p.creature)
+		if !ok {
+			return nil, fmt.Errorf("%w: %d", ErrUnknownCreature, p.creature)
+		}
+		if p.colour < 0 || p.colour >= MaxPlayers {
+			return nil, fmt.Errorf("%w: unknown colour %d", ErrInvalidPosition, p.colour)
+		}
+		if p.number < 0 || p.number > ct.Count {
+			return nil, fmt.Errorf("%w: piece number %d out of range for creature %d", ErrInvalidPosition, p.number, p.creature)
+		}
+		if p.number == 0 {
+			continue
+		}
+		if _, ok := used[p]; ok {
+			return nil, fmt.Errorf("%w: piece %+v added more than once", ErrInvalidPosition, p)
+		}
+		used[p] = struct{}{}
+	}
+
+	for _, pp := range b.pieces {
+		p := pp.piece
+		if p.number == 0 {
+			for p.number = 1; ; p.number++ {
+				if _, ok := used[p]; !ok {
+					break
+				}
+			}
+			used[p] = struct{}{}
+		}
+		if g.checkSpaceOccupied(pp.h) && !climbers[p.creature] {
+			return nil, fmt.Errorf("%w: creature %d cannot be on top of another piece", ErrInvalidPosition, p.creature)
+		}
+		g.dropPiece(pp.h, p)
+
+		g.reserves[p.colour][p.creature]--
+		if g.reserves[p.colour][p.creature] < 0 {
+			return nil, fmt.Errorf("%w: too many pieces of creature %d for colour %d", ErrInvalidPosition, p.creature, p.colour)
+		}
+	}
+
+	if !g.ensureConnected() {
+		return nil, fmt.Errorf("%w: pieces do not form a single hive", ErrInvalidPosition)
+	}
+
+	if b.lastMoved != nil {
+		if !g.checkSpaceOccupied(*b.lastMoved) {
+			return nil, fmt.Errorf("%w: no piece at last moved hex %v", ErrInvalidPosition, *b.lastMoved)
+		}
+		g.lastMoved, g.hasMoved = *b.lastMoved, true
+	}
+
+	return g, nil
+}
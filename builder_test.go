@@ -0,0 +1,117 @@
+package hive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+func TestBuilder(t *testing.T) {
+	//     __
+	//  __/bB\
+	// /wB\__/
+	// \__/bQ\
+	//    \__/
+	origin := hexgrid.MustNew(0, 0, 0)
+	upRight := hexgrid.MustNew(1, -1, 0)
+	downRight := hexgrid.MustNew(1, 0, -1)
+	g, err := NewBuilder(Options{}).
+		Add(origin, NewPiece(Spider, White, 0)).
+		Add(origin, NewPiece(Beetle, White, 2)).
+		Add(origin, NewPiece(Beetle, White, 0)).
+		Add(upRight, NewPiece(QueenBee, White, 0)).
+		Add(upRight, NewPiece(Beetle, Black, 0)).
+		Add(downRight, NewPiece(QueenBee, Black, 1)).
+		SetToMove(Black).
+		SetMoveCount(5).
+		SetLastMoved(upRight).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	if got, want := g.Occupied(), []hexgrid.Hex{origin, upRight, downRight}; !reflect.DeepEqual(got, want) {
+		t.Errorf("Got occupied hexes %v, want %v", got, want)
+	}
+	wantStack := []Piece{NewPiece(Spider, White, 1), NewPiece(Beetle, White, 2), NewPiece(Beetle, White, 1)}
+	if got := g.Stack(origin); !reflect.DeepEqual(got, wantStack) {
+		t.Errorf("Got stack %v, want %v", got, wantStack)
+	}
+	if got, ok := g.Top(upRight); !ok || got.Creature() != Beetle || got.Colour() != Black || got.Number() != 1 {
+		t.Errorf("Got top piece %v, want the first black Beetle", got)
+	}
+	if h, height, ok := g.Find(NewPiece(Beetle, White, 1)); !ok || h != origin || height != 2 {
+		t.Errorf("Got piece at %v height %v, want %v height 2", h, height, origin)
+	}
+	if h, ok := g.LastMoved(); !ok || h != upRight {
+		t.Errorf("Got last moved %v, want %v", h, upRight)
+	}
+	if g.ToMove() != Black || g.MoveCount() != 5 || g.Turn() != 3 {
+		t.Errorf("Got %v to move on move %v turn %v, want black on move 5 turn 3", g.ToMove(), g.MoveCount(), g.Turn())
+	}
+	wantReserve := map[int]int{Spider: 1, Grasshopper: 3, SoldierAnt: 3}
+	if got := g.Reserve(White); !reflect.DeepEqual(got, wantReserve) {
+		t.Errorf("Got reserve %v, want %v", got, wantReserve)
+	}
+}
+
+func TestBuilderErrors(t *testing.T) {
+	origin := hexgrid.MustNew(0, 0, 0)
+	tests := map[string]struct {
+		builder *Builder
+		want    error
+	}{
+		"Unknown creature": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(frog+1, White, 0)),
+			want:    ErrUnknownCreature,
+		},
+		"Expansion not in use": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(Mosquito, White, 0)),
+			want:    ErrInvalidPosition,
+		},
+		"Too many pieces": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(QueenBee, White, 0)).Add(origin, NewPiece(QueenBee, White, 0)),
+			want:    ErrInvalidPosition,
+		},
+		"Duplicate piece": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(Beetle, White, 1)).Add(origin, NewPiece(Beetle, White, 1)),
+			want:    ErrInvalidPosition,
+		},
+		"Number out of range": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(Beetle, White, 3)),
+			want:    ErrInvalidPosition,
+		},
+		"Disconnected": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(Beetle, White, 0)).Add(hexgrid.MustNew(2, 0, -2), NewPiece(Beetle, Black, 0)),
+			want:    ErrInvalidPosition,
+		},
+		"Ground piece on top": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(QueenBee, White, 0)).Add(origin, NewPiece(Spider, Black, 0)),
+			want:    ErrInvalidPosition,
+		},
+		"Mosquito on top": {
+			builder: NewBuilder(Options{Expansions: []int{Mosquito}}).Add(origin, NewPiece(QueenBee, White, 0)).Add(origin, NewPiece(Mosquito, Black, 0)).SetMoveCount(2),
+		},
+		"Wrong player to move": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(Beetle, White, 0)).SetMoveCount(7),
+			want:    ErrInvalidPosition,
+		},
+		"Black to move first": {
+			builder: NewBuilder(Options{}).SetToMove(Black),
+			want:    ErrInvalidPosition,
+		},
+		"Last moved empty": {
+			builder: NewBuilder(Options{}).Add(origin, NewPiece(Beetle, White, 0)).SetLastMoved(hexgrid.MustNew(1, 0, -1)),
+			want:    ErrInvalidPosition,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := tc.builder.Build(); !errors.Is(err, tc.want) {
+				t.Errorf("Got error %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
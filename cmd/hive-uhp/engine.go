@@ -0,0 +1,321 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/maze-mapper/hive"
+)
+
+// Engine identification reported by the info command
+const (
+	engineName    = "hive-uhp"
+	engineVersion = "1.0.0"
+)
+
+// Errors returned by commands that need a game in progress
+var (
+	errNoGame   = errors.New("no game in progress")
+	errGameOver = errors.New("game is over")
+)
+
+// Names of the game states and colours used in GameStrings
+var (
+	gameStates = map[int]string{
+		hive.InProgress: "InProgress",
+		hive.BlackWins:  "BlackWins",
+		hive.WhiteWins:  "WhiteWins",
+		hive.Draw:       "Draw",
+	}
+	colourNames = map[int]string{
+		hive.Black: "Black",
+		hive.White: "White",
+	}
+)
+
+// engine holds the state of a Universal Hive Protocol session
+type engine struct {
+	out      io.Writer
+	game     *hive.Game
+	gameType string
+	moves    []string // MoveStrings of the moves played so far
+}
+
+// newEngine returns an engine writing its responses to out
+func newEngine(out io.Writer) *engine {
+	return &engine{out: out}
+}
+
+// run reads commands from in until it is exhausted, writing a response to each
+func (e *engine) run(in io.Reader) error {
+	e.execute("info")
+	scanner := bufio.NewScanner(in)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		if line == "exit" {
+			break
+		}
+		e.execute(line)
+	}
+	return scanner.Err()
+}
+
+// execute runs a single command and writes its response followed by ok
+func (e *engine) execute(line string) {
+	fields := strings.Fields(line)
+	command, args := fields[0], strings.TrimSpace(strings.TrimPrefix(line, fields[0]))
+
+	var response string
+	var err error
+	switch command {
+	case "info":
+		response = e.info()
+	case "newgame":
+		response, err = e.newGame(args)
+	case "play":
+		response, err = e.play(args)
+	case "validmoves":
+		response, err = e.validMoves()
+	case "bestmove":
+		response, err = e.bestMove()
+	case "undo":
+		response, err = e.undo(args)
+	case "options":
+		err = e.options(args)
+	default:
+		err = fmt.Errorf("unknown command %q", command)
+	}
+
+	// Only a move sent with play is reported as invalid, other commands fail with an error
+	switch {
+	case command == "play" && isInvalidMove(err):
+		fmt.Fprintf(e.out, "invalidmove %v\n", err)
+	case err != nil:
+		fmt.Fprintf(e.out, "err %v\n", err)
+	case response != "":
+		fmt.Fprintln(e.out, response)
+	}
+	fmt.Fprintln(e.out, "ok")
+}
+
+// isInvalidMove returns true if an error was caused by the move played rather than the command
+func isInvalidMove(err error) bool {
+	for _, target := range []error{
+		hive.ErrInvalidNotation,
+		hive.ErrIllegalMove,
+		hive.ErrNotYourTurn,
+		hive.ErrNotInReserve,
+		hive.ErrGameOver,
+		hive.ErrNoPiece,
+	} {
+		if errors.Is(err, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// info returns the engine identification and the expansions it supports
+func (e *engine) info() string {
+	var capabilities []string
+	for _, creature := range hive.GetCreatures() {
+		if ct, _ := hive.GetCreatureType(creature); ct.Expansion {
+			capabilities = append(capabilities, ct.Name)
+		}
+	}
+	return fmt.Sprintf("id %s v%s\n%s", engineName, engineVersion, strings.Join(capabilities, ";"))
+}
+
+// newGame starts a game from an optional GameTypeString or GameString
+func (e *engine) newGame(args string) (string, error) {
+	if args == "" {
+		args = hive.BaseGameType
+	}
+	parts := strings.Split(args, ";")
+
+	// Errors are not wrapped so that a bad GameString is reported as an error rather than an invalid move
+	opts, err := hive.ParseGameType(parts[0])
+	if err != nil {
+		return "", fmt.Errorf("invalid GameString %q: %v", args, err)
+	}
+	// UHP games follow the tournament rule that the Queen Bee cannot be placed on a player's first turn
+	opts.NoQueenFirstTurn = true
+	game, err := hive.NewGame(opts)
+	if err != nil {
+		return "", err
+	}
+
+	previous := *e
+	e.game, e.gameType, e.moves = game, hive.FormatGameType(opts), nil
+	if len(parts) > 1 {
+		if len(parts) < 3 {
+			*e = previous
+			return "", fmt.Errorf("invalid GameString %q", args)
+		}
+		for _, s := range parts[3:] {
+			if _, err := e.play(s); err != nil {
+				*e = previous
+				return "", fmt.Errorf("invalid GameString %q: %v", args, err)
+			}
+		}
+	}
+	str, err := e.gameString()
+	if err != nil {
+		*e = previous
+		return "", err
+	}
+	// The state and turn given must be those reached by the moves
+	if got := strings.Split(str, ";"); len(parts) > 1 && (parts[1] != got[1] || parts[2] != got[2]) {
+		*e = previous
+		return "", fmt.Errorf("invalid GameString %q: moves lead to %s;%s", args, got[1], got[2])
+	}
+	return str, nil
+}
+
+// play parses a MoveString and plays it.
+// Errors name the MoveString as sent rather than the move it was parsed to.
+func (e *engine) play(s string) (string, error) {
+	if e.game == nil {
+		return "", errNoGame
+	}
+	m, err := e.game.ParseMove(s)
+	if err != nil {
+		return "", fmt.Errorf("cannot play %q: %w", s, err)
+	}
+	// Format before playing so the recorded MoveString refers to the position it was played in
+	str, err := e.game.FormatMove(m)
+	if err != nil {
+		return "", fmt.Errorf("cannot play %q: %w", s, err)
+	}
+	if err := e.game.Play(m); err != nil {
+		var moveErr *hive.MoveError
+		if errors.As(err, &moveErr) {
+			err = moveErr.Err
+		}
+		return "", fmt.Errorf("cannot play %q: %w", s, err)
+	}
+	e.moves = append(e.moves, str)
+	return e.gameString()
+}
+
+// validMoveStrings returns the sorted MoveStrings of all legal moves in a game still in progress
+func (e *engine) validMoveStrings() ([]string, map[string]hive.Move, error) {
+	if e.game == nil {
+		return nil, nil, errNoGame
+	}
+	result, err := e.game.Result()
+	if err != nil {
+		return nil, nil, err
+	}
+	if result != hive.InProgress {
+		return nil, nil, errGameOver
+	}
+	moves, err := e.game.GetLegalMoves()
+	if err != nil {
+		return nil, nil, err
+	}
+	byString := map[string]hive.Move{}
+	var strs []string
+	for _, m := range moves {
+		str, err := e.game.FormatMove(m)
+		if err != nil {
+			return nil, nil, err
+		}
+		if _, ok := byString[str]; !ok {
+			byString[str] = m
+			strs = append(strs, str)
+		}
+	}
+	sort.Strings(strs)
+	return strs, byString, nil
+}
+
+// validMoves returns the legal moves separated by semicolons
+func (e *engine) validMoves() (string, error) {
+	strs, _, err := e.validMoveStrings()
+	if err != nil {
+		return "", err
+	}
+	return strings.Join(strs, ";"), nil
+}
+
+// bestMove returns a move that wins immediately if there is one, otherwise the first legal move.
+// Time and depth limits are accepted but ignored.
+func (e *engine) bestMove() (string, error) {
+	strs, byString, err := e.validMoveStrings()
+	if err != nil {
+		return "", err
+	}
+	win := hive.WhiteWins
+	if e.game.ToMove() == hive.Black {
+		win = hive.BlackWins
+	}
+	for _, str := range strs {
+		g := e.game.Copy()
+		if err := g.Play(byString[str]); err != nil {
+			return "", err
+		}
+		if result, err := g.Result(); err == nil && result == win {
+			return str, nil
+		}
+	}
+	return strs[0], nil
+}
+
+// undo takes back one or more moves
+func (e *engine) undo(args string) (string, error) {
+	if e.game == nil {
+		return "", errNoGame
+	}
+	n := 1
+	if args != "" {
+		var err error
+		if n, err = strconv.Atoi(args); err != nil || n < 1 {
+			return "", fmt.Errorf("invalid number of moves to undo %q", args)
+		}
+	}
+	if n > len(e.moves) {
+		return "", fmt.Errorf("cannot undo %d moves, only %d played", n, len(e.moves))
+	}
+	for i := 0; i < n; i++ {
+		if err := e.game.Undo(); err != nil {
+			return "", err
+		}
+	}
+	e.moves = e.moves[:len(e.moves)-n]
+	return e.gameString()
+}
+
+// options handles the options command. The engine has no options to report or set.
+func (e *engine) options(args string) error {
+	fields := strings.Fields(args)
+	if len(fields) == 0 {
+		return nil
+	}
+	if len(fields) >= 2 && (fields[0] == "get" || fields[0] == "set") {
+		return fmt.Errorf("unknown option %q", fields[1])
+	}
+	return fmt.Errorf("invalid options command %q", args)
+}
+
+// gameString returns the GameString describing the current game
+func (e *engine) gameString() (string, error) {
+	state := "NotStarted"
+	if len(e.moves) > 0 {
+		result, err := e.game.Result()
+		if err != nil {
+			return "", err
+		}
+		state = gameStates[result]
+	}
+	turn := fmt.Sprintf("%s[%d]", colourNames[e.game.ToMove()], e.game.Turn())
+	return strings.Join(append([]string{e.gameType, state, turn}, e.moves...), ";"), nil
+}
@@ -0,0 +1,133 @@
+package main
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestEngine(t *testing.T) {
+	info := "id hive-uhp v1.0.0\nMosquito;Ladybug;Pillbug\nok\n"
+
+	tests := map[string]struct {
+		input string
+		want  string
+	}{
+		"Info": {
+			input: "info\n",
+			want:  info + info,
+		},
+		"New game": {
+			input: "newgame\nnewgame Base+MLP\n",
+			want:  info + "Base;NotStarted;White[1]\nok\nBase+MLP;NotStarted;White[1]\nok\n",
+		},
+		"Invalid game type": {
+			input: "newgame Base+X\n",
+			want:  info + "err invalid GameString \"Base+X\": invalid notation: unknown expansion 'X' in game type \"Base+X\"\nok\n",
+		},
+		"Play": {
+			input: "newgame\nplay wS1\nplay bG1 -wS1\nplay pass\n",
+			want: info +
+				"Base;NotStarted;White[1]\nok\n" +
+				"Base;InProgress;Black[1];wS1\nok\n" +
+				"Base;InProgress;White[2];wS1;bG1 -wS1\nok\n" +
+				"invalidmove cannot play \"pass\": move is not allowed\nok\n",
+		},
+		"Invalid move string": {
+			input: "newgame\nplay wZ1\n",
+			want: info +
+				"Base;NotStarted;White[1]\nok\n" +
+				"invalidmove cannot play \"wZ1\": invalid notation: unknown creature in piece \"wZ1\"\nok\n",
+		},
+		"Invalid game string": {
+			input: "newgame\nplay wS1\nnewgame Base;InProgress;White[2];wS1;bS1 wS1-;wQ wS1-\nplay bG1 -wS1\n",
+			want: info +
+				"Base;NotStarted;White[1]\nok\n" +
+				"Base;InProgress;Black[1];wS1\nok\n" +
+				"err invalid GameString \"Base;InProgress;White[2];wS1;bS1 wS1-;wQ wS1-\": cannot play \"wQ wS1-\": move is not allowed\nok\n" +
+				"Base;InProgress;White[2];wS1;bG1 -wS1\nok\n",
+		},
+		"Game string state mismatch": {
+			input: "newgame Base;InProgress;White[9];wS1\n",
+			want:  info + "err invalid GameString \"Base;InProgress;White[9];wS1\": moves lead to InProgress;Black[1]\nok\n",
+		},
+		"Load game string": {
+			input: "newgame Base;InProgress;Black[2];wS1;bS1 wS1-;wQ -wS1\n",
+			want:  info + "Base;InProgress;Black[2];wS1;bS1 wS1-;wQ -wS1\nok\n",
+		},
+		"Valid moves": {
+			input: "newgame\nplay wS1\nvalidmoves\n",
+			want: info +
+				"Base;NotStarted;White[1]\nok\n" +
+				"Base;InProgress;Black[1];wS1\nok\n" +
+				`bA1 -wS1;bA1 /wS1;bA1 \wS1;bA1 wS1-;bA1 wS1/;bA1 wS1\;` +
+				`bB1 -wS1;bB1 /wS1;bB1 \wS1;bB1 wS1-;bB1 wS1/;bB1 wS1\;` +
+				`bG1 -wS1;bG1 /wS1;bG1 \wS1;bG1 wS1-;bG1 wS1/;bG1 wS1\;` +
+				`bS1 -wS1;bS1 /wS1;bS1 \wS1;bS1 wS1-;bS1 wS1/;bS1 wS1\` + "\nok\n",
+		},
+		"Queen on first turn": {
+			input: "newgame\nplay wQ\n",
+			want: info +
+				"Base;NotStarted;White[1]\nok\n" +
+				"invalidmove cannot play \"wQ\": move is not allowed\nok\n",
+		},
+		"Best move": {
+			input: "newgame\nplay wS1\nbestmove time 00:00:01\n",
+			want: info +
+				"Base;NotStarted;White[1]\nok\n" +
+				"Base;InProgress;Black[1];wS1\nok\n" +
+				"bA1 -wS1\nok\n",
+		},
+		"Best move wins": {
+			// White surrounds the Black Queen by moving the second Ant to its last empty neighbour
+			input: `newgame Base;InProgress;White[6];wS1;bS1 wS1/;wQ /wS1;bQ bS1/;wA1 wQ-;bG1 bQ/;wA2 -wS1;bG2 bQ-;wA1 /bG2;bG3 -bG1` + "\nbestmove depth 1\n",
+			want: info +
+				`Base;InProgress;White[6];wS1;bS1 wS1/;wQ /wS1;bQ bS1/;wA1 wQ-;bG1 bQ/;wA2 -wS1;bG2 bQ-;wA1 /bG2;bG3 -bG1` + "\nok\n" +
+				"wA2 /bG3\nok\n",
+		},
+		"Best move game over": {
+			input: `newgame Base;InProgress;White[6];wS1;bS1 wS1/;wQ /wS1;bQ bS1/;wA1 wQ-;bG1 bQ/;wA2 -wS1;bG2 bQ-;wA1 /bG2;bG3 -bG1` + "\nplay wA2 /bG3\nbestmove depth 1\nvalidmoves\n",
+			want: info +
+				`Base;InProgress;White[6];wS1;bS1 wS1/;wQ /wS1;bQ bS1/;wA1 wQ-;bG1 bQ/;wA2 -wS1;bG2 bQ-;wA1 /bG2;bG3 -bG1` + "\nok\n" +
+				`Base;WhiteWins;Black[6];wS1;bS1 wS1/;wQ /wS1;bQ bS1/;wA1 wQ-;bG1 bQ/;wA2 -wS1;bG2 bQ-;wA1 /bG2;bG3 -bG1;wA2 /bG3` + "\nok\n" +
+				"err game is over\nok\nerr game is over\nok\n",
+		},
+		"Undo": {
+			input: "newgame\nplay wS1\nplay bG1 -wS1\nundo 2\nundo\n",
+			want: info +
+				"Base;NotStarted;White[1]\nok\n" +
+				"Base;InProgress;Black[1];wS1\nok\n" +
+				"Base;InProgress;White[2];wS1;bG1 -wS1\nok\n" +
+				"Base;NotStarted;White[1]\nok\n" +
+				"err cannot undo 1 moves, only 0 played\nok\n",
+		},
+		"No game": {
+			input: "validmoves\nplay wS1\n",
+			want:  info + "err no game in progress\nok\nerr no game in progress\nok\n",
+		},
+		"Options": {
+			input: "options\noptions get Depth\n",
+			want:  info + "ok\nerr unknown option \"Depth\"\nok\n",
+		},
+		"Pass is played": {
+			input: "newgame\npass\n",
+			want:  info + "Base;NotStarted;White[1]\nok\nerr unknown command \"pass\"\nok\n",
+		},
+		"Unknown command": {
+			input: "frobnicate\nexit\ninfo\n",
+			want:  info + "err unknown command \"frobnicate\"\nok\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var out bytes.Buffer
+			if err := newEngine(&out).run(strings.NewReader(test.input)); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if got := out.String(); got != test.want {
+				t.Errorf("Got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
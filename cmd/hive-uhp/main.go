@@ -0,0 +1,13 @@
+// Command hive-uhp is a Hive engine speaking the Universal Hive Protocol over stdin and stdout
+package main
+
+import (
+	"log"
+	"os"
+)
+
+func main() {
+	if err := newEngine(os.Stdout).run(os.Stdin); err != nil {
+		log.Fatal(err)
+	}
+}
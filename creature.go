@@ -0,0 +1,164 @@
+package hive
+
+import (
+	"errors"
+	"sort"
+	"sync"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// Mover generates the movement of a creature
+type Mover interface {
+	// Moves returns the hexes a piece can move to from h.
+	// The piece has already been lifted from h and moving it does not break the one hive rule.
+	Moves(h hexgrid.Hex, g Game) []hexgrid.Hex
+}
+
+// MoverFunc allows an ordinary function to be used as a Mover
+type MoverFunc func(h hexgrid.Hex, g Game) []hexgrid.Hex
+
+// Moves calls f(h, g)
+func (f MoverFunc) Moves(h hexgrid.Hex, g Game) []hexgrid.Hex {
+	return f(h, g)
+}
+
+// CreatureType holds the behaviour and metadata of a creature
+type CreatureType struct {
+	Name      string // Human readable name
+	Letter    byte   // Upper case letter identifying the creature in move notation
+	Count     int    // Number of pieces of this creature each player starts with
+	Expansion bool   // Only included in games that list the creature in Options.Expansions
+	Mover     Mover  // Generates the movement of the creature
+}
+
+// Errors returned when registering a creature
+var (
+	ErrCreatureRegistered = errors.New("creature is already registered")
+	ErrLetterRegistered   = errors.New("creature letter is already registered")
+	ErrNoMover            = errors.New("creature type has no mover")
+	ErrInvalidLetter      = errors.New("creature letter is not an upper case letter")
+	ErrInvalidCount       = errors.New("creature count must be at least one")
+)
+
+// Registry of creature types
+var (
+	creatureTypes   = map[int]CreatureType{}
+	creatureTypesMu sync.RWMutex
+)
+
+func init() {
+	builtIn := map[int]CreatureType{
+		QueenBee: {
+			Name: "Queen Bee", Letter: 'Q', Count: 1,
+			Mover: MoverFunc(func(h hexgrid.Hex, g Game) []hexgrid.Hex {
+				return getAvailableAdjacentMoves(h, g, false)
+			}),
+		},
+		Beetle: {
+			Name: "Beetle", Letter: 'B', Count: 2,
+			Mover: MoverFunc(func(h hexgrid.Hex, g Game) []hexgrid.Hex {
+				return getAvailableAdjacentMoves(h, g, true)
+			}),
+		},
+		Spider: {
+			Name: "Spider", Letter: 'S', Count: 2,
+			Mover: MoverFunc(func(h hexgrid.Hex, g Game) []hexgrid.Hex {
+				return getAvailableBFSMovesAtDepth(h, g, 3)
+			}),
+		},
+		Grasshopper: {
+			Name: "Grasshopper", Letter: 'G', Count: 3,
+			Mover: MoverFunc(getAvailableJumpMoves),
+		},
+		SoldierAnt: {
+			Name: "Soldier Ant", Letter: 'A', Count: 3,
+			Mover: MoverFunc(getAllAvailableBFSMoves),
+		},
+		Mosquito: {
+			Name: "Mosquito", Letter: 'M', Count: 1, Expansion: true,
+			Mover: MoverFunc(getMosquitoMoves),
+		},
+		Ladybug: {
+			Name: "Ladybug", Letter: 'L', Count: 1, Expansion: true,
+			Mover: MoverFunc(getLadybugMoves),
+		},
+		Pillbug: {
+			Name: "Pillbug", Letter: 'P', Count: 1, Expansion: true,
+			Mover: MoverFunc(func(h hexgrid.Hex, g Game) []hexgrid.Hex {
+				return getAvailableAdjacentMoves(h, g, false)
+			}),
+		},
+	}
+	for creature, ct := range builtIn {
+		creatureTypes[creature] = ct
+	}
+}
+
+// RegisterCreature adds a creature type to the registry so it can be used in games.
+// Custom creatures should use values above the built-in creatures.
+// The creature type needs a Mover, an upper case letter not already in use and a count of at least one.
+func RegisterCreature(creature int, ct CreatureType) error {
+	if ct.Mover == nil {
+		return ErrNoMover
+	}
+	if ct.Letter < 'A' || ct.Letter > 'Z' {
+		return ErrInvalidLetter
+	}
+	if ct.Count < 1 {
+		return ErrInvalidCount
+	}
+
+	creatureTypesMu.Lock()
+	defer creatureTypesMu.Unlock()
+
+	if _, ok := creatureTypes[creature]; ok {
+		return ErrCreatureRegistered
+	}
+	for _, existing := range creatureTypes {
+		if existing.Letter == ct.Letter {
+			return ErrLetterRegistered
+		}
+	}
+	creatureTypes[creature] = ct
+	return nil
+}
+
+// GetCreatureType returns the registered type of a creature
+func GetCreatureType(creature int) (CreatureType, bool) {
+	creatureTypesMu.RLock()
+	defer creatureTypesMu.RUnlock()
+
+	ct, ok := creatureTypes[creature]
+	return ct, ok
+}
+
+// GetCreatures returns all registered creatures in ascending order
+func GetCreatures() []int {
+	creatureTypesMu.RLock()
+	defer creatureTypesMu.RUnlock()
+
+	creatures := make([]int, 0, len(creatureTypes))
+	for creature := range creatureTypes {
+		creatures = append(creatures, creature)
+	}
+	sort.Ints(creatures)
+	return creatures
+}
+
+// getStartingSet returns the number of each creature a player starts a game with
+func getStartingSet(opts Options) map[int]int {
+	set := map[int]int{}
+	for _, creature := range GetCreatures() {
+		ct, _ := GetCreatureType(creature)
+		if !ct.Expansion {
+			set[creature] = ct.Count
+		}
+	}
+	for _, creature := range opts.Expansions {
+		if ct, ok := GetCreatureType(creature); ok && ct.Expansion {
+			set[creature] = ct.Count
+		}
+	}
+	return set
+}
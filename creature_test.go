@@ -0,0 +1,101 @@
+package hive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// frog is a house rule creature that leaps two spaces in a straight line in to an empty hex
+const frog = 100
+
+func init() {
+	err := RegisterCreature(frog, CreatureType{
+		Name: "Frog", Letter: 'F', Count: 2, Expansion: true,
+		Mover: MoverFunc(func(h hexgrid.Hex, g Game) []hexgrid.Hex {
+			moves := []hexgrid.Hex{}
+			for direction := 0; direction < hexgrid.MaxDirections; direction++ {
+				next := h.Move(direction)
+				dest := next.Move(direction)
+				if !g.checkSpaceOccupied(dest) {
+					moves = append(moves, dest)
+				}
+			}
+			return moves
+		}),
+	})
+	if err != nil {
+		panic(err)
+	}
+}
+
+func TestRegisterCreature(t *testing.T) {
+	mover := MoverFunc(func(h hexgrid.Hex, g Game) []hexgrid.Hex { return nil })
+	tests := map[string]struct {
+		creature int
+		ct       CreatureType
+		want     error
+	}{
+		"Built-in creature": {creature: QueenBee, ct: CreatureType{Letter: 'X', Count: 1, Mover: mover}, want: ErrCreatureRegistered},
+		"Letter in use":     {creature: frog + 1, ct: CreatureType{Letter: 'Q', Count: 1, Mover: mover}, want: ErrLetterRegistered},
+		"No mover":          {creature: frog + 1, ct: CreatureType{Letter: 'X', Count: 1}, want: ErrNoMover},
+		"Lower case letter": {creature: frog + 1, ct: CreatureType{Letter: 'x', Count: 1, Mover: mover}, want: ErrInvalidLetter},
+		"Colour letter":     {creature: frog + 1, ct: CreatureType{Letter: 'b', Count: 1, Mover: mover}, want: ErrInvalidLetter},
+		"Digit letter":      {creature: frog + 1, ct: CreatureType{Letter: '1', Count: 1, Mover: mover}, want: ErrInvalidLetter},
+		"Missing letter":    {creature: frog + 1, ct: CreatureType{Count: 1, Mover: mover}, want: ErrInvalidLetter},
+		"No pieces":         {creature: frog + 1, ct: CreatureType{Letter: 'X', Mover: mover}, want: ErrInvalidCount},
+		"Negative count":    {creature: frog + 1, ct: CreatureType{Letter: 'X', Count: -1, Mover: mover}, want: ErrInvalidCount},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if err := RegisterCreature(tc.creature, tc.ct); !errors.Is(err, tc.want) {
+				t.Errorf("Got error %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetCreatureType(t *testing.T) {
+	ct, ok := GetCreatureType(SoldierAnt)
+	if !ok || ct.Name != "Soldier Ant" || ct.Letter != 'A' || ct.Count != 3 || ct.Expansion {
+		t.Errorf("Got %+v, want the standard Soldier Ant", ct)
+	}
+	if _, ok := GetCreatureType(frog + 1); ok {
+		t.Errorf("Got a creature type for an unregistered creature")
+	}
+}
+
+func TestCustomCreature(t *testing.T) {
+	//     __
+	//  __/QB\
+	// /*F\__/
+	// \__/
+	g := Game{
+		positions: map[hexgrid.Hex][]Piece{
+			hexgrid.MustNew(0, 0, 0):  {Piece{creature: frog, colour: White}},
+			hexgrid.MustNew(1, -1, 0): {Piece{creature: QueenBee, colour: White}},
+		},
+	}
+	got, err := GetAvailableMoves(hexgrid.MustNew(0, 0, 0), g)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := []hexgrid.Hex{
+		hexgrid.MustNew(0, -2, 2), hexgrid.MustNew(2, -2, 0), hexgrid.MustNew(2, 0, -2), hexgrid.MustNew(0, 2, -2), hexgrid.MustNew(-2, 2, 0), hexgrid.MustNew(-2, 0, 2),
+	}
+	if !hexSlicesAreEqual(got, want) {
+		t.Errorf("Got %v, want %v", got, want)
+	}
+
+	newGame, err := NewGame(Options{Expansions: []int{frog}})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	reserve := newGame.Reserve(White)
+	wantReserve := map[int]int{QueenBee: 1, Beetle: 2, Spider: 2, Grasshopper: 3, SoldierAnt: 3, frog: 2}
+	if !reflect.DeepEqual(reserve, wantReserve) {
+		t.Errorf("Got reserve %v, want %v", reserve, wantReserve)
+	}
+}
@@ -1,5 +1,14 @@
 package hexgrid
 
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCoordinates is returned when cube coordinates do not sum to zero
+var ErrInvalidCoordinates = errors.New("invalid hexgrid coordinates")
+
 // Hex represents a coordinate on a hexagonal grid
 // +s ____
 //   /    \
@@ -12,11 +21,66 @@ type Hex struct {
 }
 
 // New returns a new Hex
-func New(q, r, s int) Hex {
+func New(q, r, s int) (Hex, error) {
 	if q+r+s != 0 {
-		panic("Invalid hexgrid coordinates")
+		return Hex{}, fmt.Errorf("%w: %d + %d + %d != 0", ErrInvalidCoordinates, q, r, s)
+	}
+	return Hex{q, r, s}, nil
+}
+
+// MustNew returns a new Hex and panics if the coordinates are invalid.
+// It simplifies creating hexes from known good coordinates.
+func MustNew(q, r, s int) Hex {
+	h, err := New(q, r, s)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
+
+// Q returns the q coordinate of a Hex
+func (h Hex) Q() int {
+	return h.q
+}
+
+// R returns the r coordinate of a Hex
+func (h Hex) R() int {
+	return h.r
+}
+
+// S returns the s coordinate of a Hex
+func (h Hex) S() int {
+	return h.s
+}
+
+// hexJSON is the JSON encoding of a Hex.
+// Pointers distinguish missing coordinates from zero.
+type hexJSON struct {
+	Q *int `json:"q"`
+	R *int `json:"r"`
+	S *int `json:"s"`
+}
+
+// MarshalJSON encodes a Hex as an object with q, r and s fields
+func (h Hex) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hexJSON{&h.q, &h.r, &h.s})
+}
+
+// UnmarshalJSON decodes a Hex from an object with q, r and s fields, which must all be present and sum to zero
+func (h *Hex) UnmarshalJSON(data []byte) error {
+	var v hexJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	if v.Q == nil || v.R == nil || v.S == nil {
+		return fmt.Errorf("%w: missing coordinate in %s", ErrInvalidCoordinates, data)
+	}
+	hex, err := New(*v.Q, *v.R, *v.S)
+	if err != nil {
+		return err
 	}
-	return Hex{q, r, s}
+	*h = hex
+	return nil
 }
 
 // HexDirectionVectors are the unit vectors to move to an adjacent hex
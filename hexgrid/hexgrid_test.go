@@ -1,6 +1,8 @@
 package hexgrid
 
 import (
+	"encoding/json"
+	"errors"
 	"reflect"
 	"testing"
 )
@@ -57,3 +59,63 @@ func TestGetAdjacent(t *testing.T) {
 		}
 	})
 }
+
+func TestNew(t *testing.T) {
+	tests := map[string]struct {
+		q, r, s int
+		want    error
+	}{
+		"Valid":   {q: 1, r: -2, s: 1},
+		"Invalid": {q: 1, r: 1, s: 1, want: ErrInvalidCoordinates},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := New(tc.q, tc.r, tc.s)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Got error %v, want %v", err, tc.want)
+			}
+			if err == nil && got != (Hex{tc.q, tc.r, tc.s}) {
+				t.Errorf("Got %v, want %v", got, Hex{tc.q, tc.r, tc.s})
+			}
+		})
+	}
+}
+
+func TestCoordinates(t *testing.T) {
+	h := Hex{2, -3, 1}
+	if h.Q() != 2 || h.R() != -3 || h.S() != 1 {
+		t.Errorf("Got %d, %d, %d, want 2, -3, 1", h.Q(), h.R(), h.S())
+	}
+}
+
+func TestJSON(t *testing.T) {
+	h := Hex{2, -3, 1}
+	data, err := json.Marshal(h)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if want := `{"q":2,"r":-3,"s":1}`; string(data) != want {
+		t.Errorf("Got %s, want %s", data, want)
+	}
+
+	tests := map[string]struct {
+		data string
+		want error
+	}{
+		"Valid":              {data: `{"q":2,"r":-3,"s":1}`},
+		"Invalid":            {data: `{"q":2,"r":-3,"s":2}`, want: ErrInvalidCoordinates},
+		"Missing coordinate": {data: `{"q":2,"r":-2}`, want: ErrInvalidCoordinates},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got Hex
+			err := json.Unmarshal([]byte(tc.data), &got)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Got error %v, want %v", err, tc.want)
+			}
+			if err == nil && got != h {
+				t.Errorf("Got %v, want %v", got, h)
+			}
+		})
+	}
+}
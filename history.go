@@ -0,0 +1,114 @@
+package hive
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// Errors returned when stepping through the history of a game
+var (
+	ErrNothingToUndo  = errors.New("no moves to undo")
+	ErrNothingToRedo  = errors.New("no moves to redo")
+	ErrMoveOutOfRange = errors.New("move is outside the recorded history")
+)
+
+// HistoryEntry records a move played in a game
+type HistoryEntry struct {
+	Move  Move    // Move as it was played
+	Piece Piece   // Piece placed or moved, the zero Piece for a pass
+	Stack []Piece // Resulting stack at the destination, empty for a pass
+
+	lastMoved hexgrid.Hex // Last moved hex before the move was played
+	hasMoved  bool        // Whether a piece had been played before the move was played
+}
+
+// apply makes a move that is known to be legal and records it in the history
+func (g *Game) apply(entry HistoryEntry) {
+	entry.lastMoved, entry.hasMoved = g.lastMoved, g.hasMoved
+
+	m := entry.Move
+	switch m.Kind {
+	case Placement:
+		g.dropPiece(m.To, entry.Piece)
+		g.reserves[entry.Piece.colour][entry.Piece.creature]--
+	case Movement:
+		g.dropPiece(m.To, g.liftPiece(m.From))
+	}
+	if m.Kind != Pass {
+		entry.Stack = g.positions[m.To]
+	}
+
+	g.lastMoved = m.To
+	g.hasMoved = m.Kind != Pass
+	g.moveCount++
+	g.toMove = (g.toMove + 1) % MaxPlayers
+	g.history = append(g.history, entry)
+}
+
+// History returns the moves played in a game, oldest first
+func (g *Game) History() []HistoryEntry {
+	history := make([]HistoryEntry, len(g.history))
+	for i, entry := range g.history {
+		entry.Stack = append([]Piece{}, entry.Stack...)
+		history[i] = entry
+	}
+	return history
+}
+
+// Undo takes back the most recent move
+func (g *Game) Undo() error {
+	if len(g.history) == 0 {
+		return ErrNothingToUndo
+	}
+	entry := g.history[len(g.history)-1]
+	g.history = g.history[:len(g.history)-1]
+
+	m := entry.Move
+	switch m.Kind {
+	case Placement:
+		g.liftPiece(m.To)
+		g.reserves[entry.Piece.colour][entry.Piece.creature]++
+	case Movement:
+		g.dropPiece(m.From, g.liftPiece(m.To))
+	}
+
+	g.lastMoved, g.hasMoved = entry.lastMoved, entry.hasMoved
+	g.moveCount--
+	g.toMove = (g.toMove + MaxPlayers - 1) % MaxPlayers
+	g.undone = append(g.undone, entry)
+	return nil
+}
+
+// Redo plays the most recently undone move again
+func (g *Game) Redo() error {
+	if len(g.undone) == 0 {
+		return ErrNothingToRedo
+	}
+	entry := g.undone[len(g.undone)-1]
+	g.undone = g.undone[:len(g.undone)-1]
+	g.apply(entry)
+	return nil
+}
+
+// GoTo undoes or redoes moves until the given number of moves have been played
+func (g *Game) GoTo(moveCount int) error {
+	first := g.moveCount - len(g.history)
+	last := g.moveCount + len(g.undone)
+	if moveCount < first || moveCount > last {
+		return fmt.Errorf("%w: %d not between %d and %d", ErrMoveOutOfRange, moveCount, first, last)
+	}
+
+	for g.moveCount > moveCount {
+		if err := g.Undo(); err != nil {
+			return err
+		}
+	}
+	for g.moveCount < moveCount {
+		if err := g.Redo(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,118 @@
+package hive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// sameState returns true if two games have the same position and players' state
+func sameState(a, b *Game) bool {
+	return reflect.DeepEqual(a.positions, b.positions) &&
+		reflect.DeepEqual(a.reserves, b.reserves) &&
+		a.toMove == b.toMove &&
+		a.moveCount == b.moveCount &&
+		a.lastMoved == b.lastMoved &&
+		a.hasMoved == b.hasMoved
+}
+
+// historyTestMoves is an opening where White's Beetle climbs on to Black's Queen Bee
+var historyTestMoves = []Move{
+	{Kind: Placement, Creature: Beetle, To: hexgrid.MustNew(0, 0, 0)},
+	{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(0, -1, 1)},
+	{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(1, 0, -1)},
+	{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, -2, 2)},
+	{Kind: Movement, From: hexgrid.MustNew(1, 0, -1), To: hexgrid.MustNew(1, -1, 0)},
+	{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(-1, -1, 2)},
+	{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(0, -1, 1)},
+}
+
+func TestUndoRedo(t *testing.T) {
+	g := mustNewGame(t, Options{})
+	states := []Game{g.Copy()}
+	for i, m := range historyTestMoves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+		states = append(states, g.Copy())
+	}
+
+	history := g.History()
+	if len(history) != len(historyTestMoves) {
+		t.Fatalf("Got %d history entries, want %d", len(history), len(historyTestMoves))
+	}
+	last := history[len(history)-1]
+	wantStack := []Piece{NewPiece(QueenBee, Black, 1), NewPiece(Beetle, White, 1)}
+	if last.Piece != NewPiece(Beetle, White, 1) || !reflect.DeepEqual(last.Stack, wantStack) {
+		t.Errorf("Got last entry %+v, want the White Beetle on a stack of %v", last, wantStack)
+	}
+
+	for i := len(historyTestMoves) - 1; i >= 0; i-- {
+		if err := g.Undo(); err != nil {
+			t.Fatalf("Undo %d: unexpected error %v", i, err)
+		}
+		if !sameState(g, &states[i]) {
+			t.Errorf("Undo %d: got state %+v, want %+v", i, g, states[i])
+		}
+	}
+	if err := g.Undo(); !errors.Is(err, ErrNothingToUndo) {
+		t.Errorf("Got error %v, want %v", err, ErrNothingToUndo)
+	}
+
+	for i := 1; i <= len(historyTestMoves); i++ {
+		if err := g.Redo(); err != nil {
+			t.Fatalf("Redo %d: unexpected error %v", i, err)
+		}
+		if !sameState(g, &states[i]) {
+			t.Errorf("Redo %d: got state %+v, want %+v", i, g, states[i])
+		}
+	}
+	if err := g.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Got error %v, want %v", err, ErrNothingToRedo)
+	}
+}
+
+func TestPlayClearsRedo(t *testing.T) {
+	g := mustNewGame(t, Options{})
+	for i, m := range historyTestMoves[:2] {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := g.Play(Move{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(1, 0, -1)}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := g.Redo(); !errors.Is(err, ErrNothingToRedo) {
+		t.Errorf("Got error %v, want %v", err, ErrNothingToRedo)
+	}
+}
+
+func TestGoTo(t *testing.T) {
+	g := mustNewGame(t, Options{})
+	states := []Game{g.Copy()}
+	for i, m := range historyTestMoves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+		states = append(states, g.Copy())
+	}
+
+	for _, moveCount := range []int{2, 0, 7, 3} {
+		if err := g.GoTo(moveCount); err != nil {
+			t.Fatalf("Go to %d: unexpected error %v", moveCount, err)
+		}
+		if !sameState(g, &states[moveCount]) {
+			t.Errorf("Go to %d: got state %+v, want %+v", moveCount, g, states[moveCount])
+		}
+	}
+	for _, moveCount := range []int{-1, 8} {
+		if err := g.GoTo(moveCount); !errors.Is(err, ErrMoveOutOfRange) {
+			t.Errorf("Go to %d: got error %v, want %v", moveCount, err, ErrMoveOutOfRange)
+		}
+	}
+}
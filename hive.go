@@ -1,7 +1,8 @@
 package hive
 
 import (
-	"log"
+	"fmt"
+	"sort"
 	"sync"
 
 	"github.com/maze-mapper/hive/hexgrid"
@@ -14,6 +15,9 @@ const (
 	Spider
 	Grasshopper
 	SoldierAnt
+	Mosquito
+	Ladybug
+	Pillbug
 )
 
 // Piece colour determines which player it belongs to
@@ -23,39 +27,248 @@ const (
 	MaxPlayers
 )
 
+// Options holds the rule variations used in a game
+type Options struct {
+	NoQueenFirstTurn bool  // Forbid placing the Queen Bee on a player's first turn, as in tournament play
+	Expansions       []int // Expansion creatures added to each player's pieces, see CreatureType
+}
+
 // Piece represents a creature tile
 type Piece struct {
 	creature int
 	colour   int
+	number   int // Distinguishes pieces of the same creature and colour, starting from 1
+}
+
+// NewPiece returns a piece of the given creature, colour and number
+func NewPiece(creature, colour, number int) Piece {
+	return Piece{creature: creature, colour: colour, number: number}
+}
+
+// Creature returns the creature of a piece
+func (p Piece) Creature() int {
+	return p.creature
+}
+
+// Colour returns the colour of the player a piece belongs to
+func (p Piece) Colour() int {
+	return p.colour
+}
+
+// Number returns the number distinguishing a piece from others of the same creature and colour
+func (p Piece) Number() int {
+	return p.number
 }
 
 // Game holds information on the game state
 type Game struct {
-	positions map[hexgrid.Hex]Piece // Positions occupied by a piece
+	positions map[hexgrid.Hex][]Piece // Stacks of pieces occupying a position, ordered from bottom to top
+	toMove    int                     // Colour of the player whose turn it is
+	moveCount int                     // Number of moves played so far
+	lastMoved hexgrid.Hex             // Hex of the piece played on the previous turn
+	hasMoved  bool                    // Whether a piece was played on the previous turn
+	reserves  [MaxPlayers]map[int]int // Number of each creature each player has yet to place
+	options   Options                 // Rule variations in use
+	history   []HistoryEntry          // Moves played, oldest first
+	undone    []HistoryEntry          // Moves taken back that can be redone, most recently undone last
 }
 
 // Copy returns a deep copy of a Game
 func (g *Game) Copy() Game {
-	positions := map[hexgrid.Hex]Piece{}
-	for h, piece := range g.positions {
-		positions[h] = Piece{
-			creature: piece.creature,
-			colour:   piece.colour,
+	positions := map[hexgrid.Hex][]Piece{}
+	for h, stack := range g.positions {
+		positions[h] = append([]Piece{}, stack...)
+	}
+	var reserves [MaxPlayers]map[int]int
+	for colour, reserve := range g.reserves {
+		if reserve == nil {
+			continue
+		}
+		reserves[colour] = map[int]int{}
+		for creature, count := range reserve {
+			reserves[colour][creature] = count
 		}
 	}
-	gg := Game{positions: positions}
+	gg := Game{
+		positions: positions,
+		toMove:    g.toMove,
+		moveCount: g.moveCount,
+		lastMoved: g.lastMoved,
+		hasMoved:  g.hasMoved,
+		reserves:  reserves,
+		options:   g.options,
+		history:   append([]HistoryEntry{}, g.history...),
+		undone:    append([]HistoryEntry{}, g.undone...),
+	}
 	return gg
 }
 
+// Occupied returns all hexes containing at least one piece, ordered by their q and then r coordinates
+func (g *Game) Occupied() []hexgrid.Hex {
+	hexes := make([]hexgrid.Hex, 0, len(g.positions))
+	for h := range g.positions {
+		hexes = append(hexes, h)
+	}
+	sort.Slice(hexes, func(i, j int) bool {
+		if hexes[i].Q() != hexes[j].Q() {
+			return hexes[i].Q() < hexes[j].Q()
+		}
+		return hexes[i].R() < hexes[j].R()
+	})
+	return hexes
+}
+
+// Stack returns the pieces on a hex ordered from bottom to top
+func (g *Game) Stack(h hexgrid.Hex) []Piece {
+	return append([]Piece{}, g.positions[h]...)
+}
+
+// Top returns the piece at the top of the stack on a hex
+func (g *Game) Top(h hexgrid.Hex) (Piece, bool) {
+	return g.topPiece(h)
+}
+
+// Find returns the hex and height within its stack of a piece on the board
+func (g *Game) Find(p Piece) (hexgrid.Hex, int, bool) {
+	for h, stack := range g.positions {
+		for height, piece := range stack {
+			if piece == p {
+				return h, height, true
+			}
+		}
+	}
+	return hexgrid.Hex{}, 0, false
+}
+
+// Options returns the rule variations used in a game
+func (g *Game) Options() Options {
+	return g.options
+}
+
+// MoveCount returns the number of moves played so far
+func (g *Game) MoveCount() int {
+	return g.moveCount
+}
+
+// Turn returns the number of the current turn, counting from 1 and increasing after both players have moved
+func (g *Game) Turn() int {
+	return g.moveCount/2 + 1
+}
+
+// LastMoved returns the hex of the piece played on the previous turn
+func (g *Game) LastMoved() (hexgrid.Hex, bool) {
+	return g.lastMoved, g.hasMoved
+}
+
+// nextNumber returns the lowest number not used by a piece of a creature and colour on the board
+func (g *Game) nextNumber(creature, colour int) int {
+	used := map[int]struct{}{}
+	for _, stack := range g.positions {
+		for _, piece := range stack {
+			if piece.creature == creature && piece.colour == colour {
+				used[piece.number] = struct{}{}
+			}
+		}
+	}
+	number := 1
+	for {
+		if _, ok := used[number]; !ok {
+			return number
+		}
+		number++
+	}
+}
+
 // checkSpaceOccupied returns true if a space is occupied by a piece
 func (g *Game) checkSpaceOccupied(h hexgrid.Hex) bool {
-	_, ok := g.positions[h]
-	return ok
+	return len(g.positions[h]) > 0
+}
+
+// topPiece returns the piece at the top of the stack on a hex
+func (g *Game) topPiece(h hexgrid.Hex) (Piece, bool) {
+	stack := g.positions[h]
+	if len(stack) == 0 {
+		return Piece{}, false
+	}
+	return stack[len(stack)-1], true
+}
+
+// liftPiece removes and returns the piece at the top of the stack on a hex
+func (g *Game) liftPiece(h hexgrid.Hex) Piece {
+	stack := g.positions[h]
+	piece := stack[len(stack)-1]
+	if len(stack) == 1 {
+		delete(g.positions, h)
+	} else {
+		g.positions[h] = stack[:len(stack)-1]
+	}
+	return piece
+}
+
+// dropPiece places a piece on top of the stack on a hex
+func (g *Game) dropPiece(h hexgrid.Hex, piece Piece) {
+	// Build a new slice so stacks shared with earlier states are never modified
+	stack := g.positions[h]
+	newStack := make([]Piece, len(stack), len(stack)+1)
+	copy(newStack, stack)
+	g.positions[h] = append(newStack, piece)
+}
+
+// getCommonNeighbours returns the two hexes adjacent to both of a pair of adjacent hexes
+func getCommonNeighbours(from, to hexgrid.Hex) (hexgrid.Hex, hexgrid.Hex) {
+	adjacent := from.GetAdjacent()
+	for direction, h := range adjacent {
+		if h == to {
+			prev := (direction + hexgrid.MaxDirections - 1) % hexgrid.MaxDirections
+			next := (direction + 1) % hexgrid.MaxDirections
+			return adjacent[prev], adjacent[next]
+		}
+	}
+	panic("Hexes are not adjacent")
+}
+
+// isGated returns true if a piece cannot pass between two adjacent hexes because the stacks
+// either side of the gap are both taller than the stacks at the start and destination
+func (g *Game) isGated(from, to hexgrid.Hex) bool {
+	prevHex, nextHex := getCommonNeighbours(from, to)
+
+	gateHeight := len(g.positions[prevHex])
+	if h := len(g.positions[nextHex]); h < gateHeight {
+		gateHeight = h
+	}
+
+	moveHeight := len(g.positions[from])
+	if h := len(g.positions[to]); h > moveHeight {
+		moveHeight = h
+	}
+
+	return gateHeight > moveHeight
+}
+
+// queenPlaced returns true if a player's Queen Bee is on the board
+func (g *Game) queenPlaced(colour int) bool {
+	for _, stack := range g.positions {
+		for _, piece := range stack {
+			if piece.creature == QueenBee && piece.colour == colour {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isLastMoved returns true if the piece on a hex was played on the previous turn.
+// That piece may not move, be moved or use its special ability this turn.
+func (g *Game) isLastMoved(h hexgrid.Hex) bool {
+	return g.hasMoved && g.lastMoved == h
 }
 
 // ensureConnected checks if the graph is connected to enforce the one hive rule
 func (g *Game) ensureConnected() bool {
 	// Get an arbitrary starting node (consider an empty graph to be connected)
+	if len(g.positions) == 0 {
+		return true
+	}
 	var start hexgrid.Hex
 	for k := range g.positions {
 		start = k
@@ -127,77 +340,195 @@ func BFS(start hexgrid.Hex, g *Game, neighbourFunc func(hexgrid.Hex) []hexgrid.H
 	return nodesByDepth
 }
 
-// GetAllAvailableMoves returns a map of hexes to all possible moves for a given player colour
-func GetAllAvailableMoves(g Game, colour int) map[hexgrid.Hex][]hexgrid.Hex {
+// GetAllAvailableMoves returns a map of hexes to all possible moves for a given player colour.
+// A player may not move any pieces until their Queen Bee has been placed.
+func GetAllAvailableMoves(g Game, colour int) (map[hexgrid.Hex][]hexgrid.Hex, error) {
 	moves := map[hexgrid.Hex][]hexgrid.Hex{}
+	if !g.queenPlaced(colour) {
+		return moves, nil
+	}
 	var mu sync.Mutex
 	var wg sync.WaitGroup
+	var firstErr error
 
-	for h, piece := range g.positions {
-		if piece.colour == colour {
+	for h, stack := range g.positions {
+		// Only the piece at the top of a stack is free to move
+		if stack[len(stack)-1].colour == colour && !g.isLastMoved(h) {
 			hh := h
 			wg.Add(1)
 			go func() {
-				m := GetAvailableMoves(hh, g.Copy())
+				m, err := GetAvailableMoves(hh, g.Copy())
+				mu.Lock()
+				if err != nil && firstErr == nil {
+					firstErr = err
+				}
 				if len(m) > 0 {
-					mu.Lock()
 					moves[hh] = m
-					mu.Unlock()
 				}
+				mu.Unlock()
 				wg.Done()
 			}()
 		}
 	}
 	wg.Wait()
 
-	return moves
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return moves, nil
 }
 
-// GetAvailableMoves returns the available moves for a piece
-func GetAvailableMoves(h hexgrid.Hex, g Game) []hexgrid.Hex {
-	piece, ok := g.positions[h]
+// GetAvailableMoves returns the available moves for the piece at the top of the stack on a hex
+func GetAvailableMoves(h hexgrid.Hex, g Game) ([]hexgrid.Hex, error) {
+	piece, ok := g.topPiece(h)
 	if !ok {
-		log.Fatalf("No piece at coordinate %v", h)
+		return nil, fmt.Errorf("%w: %v", ErrNoPiece, h)
+	}
+	if _, ok := GetCreatureType(piece.creature); !ok {
+		return nil, fmt.Errorf("%w: %d", ErrUnknownCreature, piece.creature)
 	}
 
-	var moves []hexgrid.Hex
+	moves := []hexgrid.Hex{}
 
 	// Remove piece from starting location to avoid invalid moves after the first
-	p := g.positions[h]
-	delete(g.positions, h)
+	stack := g.positions[h]
+	g.liftPiece(h)
 	// Add piece back at end of function
 	defer func() {
-		g.positions[h] = p
+		g.positions[h] = stack
 	}()
 
 	// Check that moving this piece does not break the one hive rule
+	// Pieces left behind in a stack keep the hex connected as a single node
 	if !g.ensureConnected() {
-		return moves
+		return moves, nil
 	}
 
+	return getCreatureMoves(piece.creature, h, g), nil
+}
+
+// getCreatureMoves returns the moves for a creature that has been lifted from a hex.
+// Unregistered creatures have no moves.
+func getCreatureMoves(creature int, h hexgrid.Hex, g Game) []hexgrid.Hex {
 	// Get available moves for the piece based on its creature
-	switch piece.creature {
+	ct, ok := GetCreatureType(creature)
+	if !ok {
+		return []hexgrid.Hex{}
+	}
+	return ct.Mover.Moves(h, g)
+}
 
-	case QueenBee:
-		moves = getAvailableAdjacentMoves(h, g, false)
+// getMosquitoMoves returns the moves of every creature adjacent to a Mosquito.
+// A Mosquito on top of the hive can only move as a Beetle.
+func getMosquitoMoves(h hexgrid.Hex, g Game) []hexgrid.Hex {
+	if g.checkSpaceOccupied(h) {
+		return getAvailableAdjacentMoves(h, g, true)
+	}
 
-	case Beetle:
-		moves = getAvailableAdjacentMoves(h, g, true)
+	// Find the creatures at the top of adjacent stacks, a Mosquito copies nothing from another Mosquito
+	creatures := map[int]struct{}{}
+	for _, neighbour := range h.GetAdjacent() {
+		if piece, ok := g.topPiece(neighbour); ok && piece.creature != Mosquito {
+			creatures[piece.creature] = struct{}{}
+		}
+	}
 
-	case Grasshopper:
-		moves = getAvailableJumpMoves(h, g)
+	found := map[hexgrid.Hex]struct{}{}
+	moves := []hexgrid.Hex{}
+	for creature := range creatures {
+		for _, move := range getCreatureMoves(creature, h, g) {
+			if _, ok := found[move]; !ok {
+				found[move] = struct{}{}
+				moves = append(moves, move)
+			}
+		}
+	}
+	return moves
+}
 
-	case Spider:
-		moves = getAvailableBFSMovesAtDepth(h, g, 3)
+// getLadybugMoves returns the hexes reached by climbing on to the hive, moving one step across
+// the top of the hive and then climbing down in to an empty space
+func getLadybugMoves(h hexgrid.Hex, g Game) []hexgrid.Hex {
+	found := map[hexgrid.Hex]struct{}{}
+	moves := []hexgrid.Hex{}
 
-	case SoldierAnt:
-		moves = getAllAvailableBFSMoves(h, g)
+	for _, first := range h.GetAdjacent() {
+		if !g.checkSpaceOccupied(first) || g.isGated(h, first) {
+			continue
+		}
+		for _, second := range first.GetAdjacent() {
+			if !g.checkSpaceOccupied(second) || g.isGated(first, second) {
+				continue
+			}
+			for _, dest := range second.GetAdjacent() {
+				if dest == h || g.checkSpaceOccupied(dest) || g.isGated(second, dest) {
+					continue
+				}
+				if _, ok := found[dest]; !ok {
+					found[dest] = struct{}{}
+					moves = append(moves, dest)
+				}
+			}
+		}
+	}
+	return moves
+}
 
-	default:
-		panic("Unrecognised creature")
+// hasThrowAbility returns true if the piece at the top of a stack can use the Pillbug's special ability.
+// A Mosquito touching a Pillbug gains the ability while it is on the ground.
+func (g *Game) hasThrowAbility(h hexgrid.Hex) bool {
+	piece, ok := g.topPiece(h)
+	if !ok || len(g.positions[h]) > 1 {
+		return false
+	}
+	switch piece.creature {
+	case Pillbug:
+		return true
+	case Mosquito:
+		for _, neighbour := range h.GetAdjacent() {
+			if p, ok := g.topPiece(neighbour); ok && p.creature == Pillbug {
+				return true
+			}
+		}
+	}
+	return false
+}
 
+// getThrows returns the moves a player can make by using a Pillbug to lift an adjacent piece
+// over itself and in to an empty space adjacent to it
+func (g *Game) getThrows(colour int) []Move {
+	moves := []Move{}
+	if !g.queenPlaced(colour) {
+		return moves
 	}
 
+	// Pieces are lifted from a copy of the board so finding moves never changes the game,
+	// which may be shared with other goroutines
+	board := g.Copy()
+	for h, stack := range g.positions {
+		if stack[len(stack)-1].colour != colour || g.isLastMoved(h) || !g.hasThrowAbility(h) {
+			continue
+		}
+
+		adjacent := h.GetAdjacent()
+		for _, from := range adjacent {
+			// Only unstacked pieces can be lifted
+			if len(g.positions[from]) != 1 || g.isLastMoved(from) {
+				continue
+			}
+
+			// Lifting the piece must not break the one hive rule or pass through a gate
+			board.liftPiece(from)
+			if board.ensureConnected() && !board.isGated(from, h) {
+				for _, to := range adjacent {
+					if to != from && !board.checkSpaceOccupied(to) && !board.isGated(h, to) {
+						moves = append(moves, Move{Kind: Movement, From: from, To: to, Ability: true, Via: h})
+					}
+				}
+			}
+			board.positions[from] = append([]Piece{}, g.positions[from]...)
+		}
+	}
 	return moves
 }
 
@@ -206,6 +537,9 @@ func getAvailableAdjacentMoves(h hexgrid.Hex, g Game, allowClimbing bool) []hexg
 	adjacent := h.GetAdjacent()
 	l := len(adjacent)
 
+	// A piece on top of the hive stays in contact with the stack it leaves behind
+	onHive := g.checkSpaceOccupied(h)
+
 	allowed := make([]bool, l)
 	for i := 0; i < l; i++ {
 		allowed[i] = true
@@ -233,12 +567,12 @@ func getAvailableAdjacentMoves(h hexgrid.Hex, g Game, allowClimbing bool) []hexg
 		nextHexOccupied := g.checkSpaceOccupied(nextHex)
 
 		// Forbid moves that take the piece out of contact with the hive
-		if !destHexOccupied && !prevHexOccupied && !nextHexOccupied {
+		if !onHive && !destHexOccupied && !prevHexOccupied && !nextHexOccupied {
 			allowed[i] = false
 		}
 
-		// Forbid moves that are prohibited due to being unable to slide
-		if !destHexOccupied && prevHexOccupied && nextHexOccupied {
+		// Forbid moves that are prohibited due to being unable to slide, climb up or climb down through a gap
+		if g.isGated(h, destHex) {
 			allowed[i] = false
 		}
 
@@ -302,8 +636,24 @@ func getAllAvailableBFSMoves(h hexgrid.Hex, g Game) []hexgrid.Hex {
 
 // GetPlacements returns all hexes where a particular colour piece could be placed
 func GetPlacements(g Game, colour int) []hexgrid.Hex {
+	// The first piece of the game is placed at the origin
+	if len(g.positions) == 0 {
+		return []hexgrid.Hex{hexgrid.MustNew(0, 0, 0)}
+	}
+
+	// The second piece of the game may be placed anywhere touching the first
+	if len(g.positions) == 1 {
+		for h, stack := range g.positions {
+			if len(stack) == 1 {
+				return h.GetAdjacent()
+			}
+		}
+	}
+
 	allPlacements := map[hexgrid.Hex]map[int]struct{}{}
-	for h, piece := range g.positions {
+	for h, stack := range g.positions {
+		// The piece at the top of a stack determines its colour
+		piece := stack[len(stack)-1]
 		neighbours := h.GetAdjacent()
 		for _, neighbour := range neighbours {
 			// Skip hexes that already contain a piece
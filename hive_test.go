@@ -1,7 +1,9 @@
 package hive
 
 import (
+	"errors"
 	"reflect"
+	"sync"
 	"testing"
 
 	"github.com/maze-mapper/hive/hexgrid"
@@ -16,7 +18,7 @@ type testCaseGame struct {
 
 // zeroPosMoves returns the slice of possible moves for the piece located at the origin
 func (tc *testCaseGame) zeroPosMoves() []hexgrid.Hex {
-	zeroPos := hexgrid.New(0, 0, 0)
+	zeroPos := hexgrid.MustNew(0, 0, 0)
 	if moves, ok := tc.moves[Black][zeroPos]; ok {
 		return moves
 	}
@@ -36,18 +38,18 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//  \__/
 	"Game 1": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):  Piece{creature: QueenBee},
-				hexgrid.New(-1, 1, 0): Piece{creature: Beetle},
-				hexgrid.New(-1, 0, 1): Piece{creature: Beetle},
-				hexgrid.New(0, -1, 1): Piece{creature: Beetle},
-				hexgrid.New(1, -1, 0): Piece{creature: Beetle},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: QueenBee}},
+				hexgrid.MustNew(-1, 1, 0): {Piece{creature: Beetle}},
+				hexgrid.MustNew(-1, 0, 1): {Piece{creature: Beetle}},
+				hexgrid.MustNew(0, -1, 1): {Piece{creature: Beetle}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Beetle}},
 			},
 		},
 		// Only use this sample for testing central queen bee, moves are incomplete
 		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
 			Black: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(0, 0, 0): []hexgrid.Hex{hexgrid.New(1, 0, -1), hexgrid.New(0, 1, -1)},
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(0, 1, -1)},
 			},
 		},
 	},
@@ -61,20 +63,20 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//  \__/  \__/
 	"Game 2": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):  Piece{creature: Beetle},
-				hexgrid.New(-1, 1, 0): Piece{creature: Beetle},
-				hexgrid.New(-1, 0, 1): Piece{creature: Beetle},
-				hexgrid.New(0, -1, 1): Piece{creature: Beetle},
-				hexgrid.New(1, -1, 0): Piece{creature: Beetle},
-				hexgrid.New(1, 0, -1): Piece{creature: Beetle},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Beetle}},
+				hexgrid.MustNew(-1, 1, 0): {Piece{creature: Beetle}},
+				hexgrid.MustNew(-1, 0, 1): {Piece{creature: Beetle}},
+				hexgrid.MustNew(0, -1, 1): {Piece{creature: Beetle}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Beetle}},
+				hexgrid.MustNew(1, 0, -1): {Piece{creature: Beetle}},
 			},
 		},
 		// Only use this sample for testing central beetle, moves are incomplete
 		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
 			Black: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(0, 0, 0): []hexgrid.Hex{
-					hexgrid.New(-1, 1, 0), hexgrid.New(-1, 0, 1), hexgrid.New(0, -1, 1), hexgrid.New(1, -1, 0), hexgrid.New(1, 0, -1),
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(-1, 0, 1), hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(1, 0, -1),
 				},
 			},
 		},
@@ -89,31 +91,31 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	// \__/  \__/
 	"Game 3": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):   Piece{creature: Beetle, colour: White},
-				hexgrid.New(-1, 1, 0):  Piece{creature: Spider, colour: White},
-				hexgrid.New(0, 1, -1):  Piece{creature: SoldierAnt, colour: White},
-				hexgrid.New(-1, 2, -1): Piece{creature: QueenBee, colour: White},
-				hexgrid.New(1, 1, -2):  Piece{creature: QueenBee, colour: Black},
-				hexgrid.New(2, 0, -2):  Piece{creature: Grasshopper, colour: Black},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):   {Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(-1, 1, 0):  {Piece{creature: Spider, colour: White}},
+				hexgrid.MustNew(0, 1, -1):  {Piece{creature: SoldierAnt, colour: White}},
+				hexgrid.MustNew(-1, 2, -1): {Piece{creature: QueenBee, colour: White}},
+				hexgrid.MustNew(1, 1, -2):  {Piece{creature: QueenBee, colour: Black}},
+				hexgrid.MustNew(2, 0, -2):  {Piece{creature: Grasshopper, colour: Black}},
 			},
 		},
 		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
 			Black: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(2, 0, -2): []hexgrid.Hex{hexgrid.New(0, 2, -2)},
+				hexgrid.MustNew(2, 0, -2): []hexgrid.Hex{hexgrid.MustNew(0, 2, -2)},
 			},
 			White: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(0, 0, 0):   []hexgrid.Hex{hexgrid.New(-1, 0, 1), hexgrid.New(-1, 1, 0), hexgrid.New(0, 1, -1), hexgrid.New(1, 0, -1)},
-				hexgrid.New(-1, 1, 0):  []hexgrid.Hex{hexgrid.New(1, -1, 0), hexgrid.New(-1, 3, -2)},
-				hexgrid.New(-1, 2, -1): []hexgrid.Hex{hexgrid.New(-2, 2, 0), hexgrid.New(0, 2, -2)},
+				hexgrid.MustNew(0, 0, 0):   []hexgrid.Hex{hexgrid.MustNew(-1, 0, 1), hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(0, 1, -1), hexgrid.MustNew(1, 0, -1)},
+				hexgrid.MustNew(-1, 1, 0):  []hexgrid.Hex{hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(-1, 3, -2)},
+				hexgrid.MustNew(-1, 2, -1): []hexgrid.Hex{hexgrid.MustNew(-2, 2, 0), hexgrid.MustNew(0, 2, -2)},
 			},
 		},
 		placements: map[int][]hexgrid.Hex{
 			Black: []hexgrid.Hex{
-				hexgrid.New(1, 2, -3), hexgrid.New(2, 1, -3), hexgrid.New(3, 0, -3), hexgrid.New(3, -1, -2), hexgrid.New(2, -1, -1),
+				hexgrid.MustNew(1, 2, -3), hexgrid.MustNew(2, 1, -3), hexgrid.MustNew(3, 0, -3), hexgrid.MustNew(3, -1, -2), hexgrid.MustNew(2, -1, -1),
 			},
 			White: []hexgrid.Hex{
-				hexgrid.New(1, -1, 0), hexgrid.New(0, -1, 1), hexgrid.New(-1, 0, 1), hexgrid.New(-2, 1, 1), hexgrid.New(-2, 2, 0), hexgrid.New(-2, 3, -1), hexgrid.New(-1, 3, -2),
+				hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(-1, 0, 1), hexgrid.MustNew(-2, 1, 1), hexgrid.MustNew(-2, 2, 0), hexgrid.MustNew(-2, 3, -1), hexgrid.MustNew(-1, 3, -2),
 			},
 		},
 	},
@@ -132,26 +134,26 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//          \__/
 	"Game 4": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):   Piece{creature: Grasshopper, colour: White},
-				hexgrid.New(0, -1, 1):  Piece{creature: QueenBee, colour: Black},
-				hexgrid.New(1, -2, 1):  Piece{creature: SoldierAnt, colour: Black},
-				hexgrid.New(2, -2, 0):  Piece{creature: SoldierAnt, colour: White},
-				hexgrid.New(2, -1, -1): Piece{creature: Spider, colour: White},
-				hexgrid.New(1, 0, -1):  Piece{creature: Grasshopper, colour: Black},
-				hexgrid.New(0, 1, -1):  Piece{creature: Beetle, colour: White},
-				hexgrid.New(2, 0, -2):  Piece{creature: QueenBee, colour: White},
-				hexgrid.New(3, -1, -2): Piece{creature: Beetle, colour: Black},
-				hexgrid.New(4, -1, -3): Piece{creature: Spider, colour: Black},
-				hexgrid.New(4, 0, -4):  Piece{creature: Spider, colour: White},
-				hexgrid.New(3, 1, -4):  Piece{creature: Spider, colour: Black},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):   {Piece{creature: Grasshopper, colour: White}},
+				hexgrid.MustNew(0, -1, 1):  {Piece{creature: QueenBee, colour: Black}},
+				hexgrid.MustNew(1, -2, 1):  {Piece{creature: SoldierAnt, colour: Black}},
+				hexgrid.MustNew(2, -2, 0):  {Piece{creature: SoldierAnt, colour: White}},
+				hexgrid.MustNew(2, -1, -1): {Piece{creature: Spider, colour: White}},
+				hexgrid.MustNew(1, 0, -1):  {Piece{creature: Grasshopper, colour: Black}},
+				hexgrid.MustNew(0, 1, -1):  {Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(2, 0, -2):  {Piece{creature: QueenBee, colour: White}},
+				hexgrid.MustNew(3, -1, -2): {Piece{creature: Beetle, colour: Black}},
+				hexgrid.MustNew(4, -1, -3): {Piece{creature: Spider, colour: Black}},
+				hexgrid.MustNew(4, 0, -4):  {Piece{creature: Spider, colour: White}},
+				hexgrid.MustNew(3, 1, -4):  {Piece{creature: Spider, colour: Black}},
 			},
 		},
 		// Incomplete move options
 		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
 			White: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(0, 0, 0): []hexgrid.Hex{
-					hexgrid.New(0, -2, 2), hexgrid.New(0, 2, -2), hexgrid.New(3, 0, -3),
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(0, -2, 2), hexgrid.MustNew(0, 2, -2), hexgrid.MustNew(3, 0, -3),
 				},
 			},
 		},
@@ -171,25 +173,25 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//    \__/
 	"Game 5": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):   Piece{creature: Spider, colour: Black},
-				hexgrid.New(1, 0, -1):  Piece{creature: Spider, colour: White},
-				hexgrid.New(2, 0, -2):  Piece{creature: QueenBee, colour: White},
-				hexgrid.New(2, 1, -3):  Piece{creature: Beetle, colour: Black},
-				hexgrid.New(2, 2, -4):  Piece{creature: SoldierAnt, colour: White},
-				hexgrid.New(1, 3, -4):  Piece{creature: Grasshopper, colour: Black},
-				hexgrid.New(0, 4, -4):  Piece{creature: Grasshopper, colour: White},
-				hexgrid.New(-1, 4, -3): Piece{creature: QueenBee, colour: Black},
-				hexgrid.New(-1, 3, -2): Piece{creature: SoldierAnt, colour: Black},
-				hexgrid.New(-1, 2, -1): Piece{creature: Beetle, colour: White},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):   {Piece{creature: Spider, colour: Black}},
+				hexgrid.MustNew(1, 0, -1):  {Piece{creature: Spider, colour: White}},
+				hexgrid.MustNew(2, 0, -2):  {Piece{creature: QueenBee, colour: White}},
+				hexgrid.MustNew(2, 1, -3):  {Piece{creature: Beetle, colour: Black}},
+				hexgrid.MustNew(2, 2, -4):  {Piece{creature: SoldierAnt, colour: White}},
+				hexgrid.MustNew(1, 3, -4):  {Piece{creature: Grasshopper, colour: Black}},
+				hexgrid.MustNew(0, 4, -4):  {Piece{creature: Grasshopper, colour: White}},
+				hexgrid.MustNew(-1, 4, -3): {Piece{creature: QueenBee, colour: Black}},
+				hexgrid.MustNew(-1, 3, -2): {Piece{creature: SoldierAnt, colour: Black}},
+				hexgrid.MustNew(-1, 2, -1): {Piece{creature: Beetle, colour: White}},
 			},
 		},
 		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
 			Black: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(0, 0, 0): []hexgrid.Hex{hexgrid.New(3, -1, -2), hexgrid.New(-2, 2, 0), hexgrid.New(0, 3, -3), hexgrid.New(1, 2, -3)},
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{hexgrid.MustNew(3, -1, -2), hexgrid.MustNew(-2, 2, 0), hexgrid.MustNew(0, 3, -3), hexgrid.MustNew(1, 2, -3)},
 			},
 			White: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(-1, 2, -1): []hexgrid.Hex{hexgrid.New(-2, 3, -1), hexgrid.New(-1, 3, -2), hexgrid.New(0, 2, -2)},
+				hexgrid.MustNew(-1, 2, -1): []hexgrid.Hex{hexgrid.MustNew(-2, 3, -1), hexgrid.MustNew(-1, 3, -2), hexgrid.MustNew(0, 2, -2)},
 			},
 		},
 	},
@@ -204,25 +206,25 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//       \__/
 	"Game 6": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):   Piece{creature: SoldierAnt, colour: Black},
-				hexgrid.New(0, -1, 1):  Piece{creature: Beetle, colour: Black},
-				hexgrid.New(0, -2, 2):  Piece{creature: QueenBee, colour: White},
-				hexgrid.New(-1, 0, 1):  Piece{creature: Beetle, colour: White},
-				hexgrid.New(-2, 0, 2):  Piece{creature: Grasshopper, colour: White},
-				hexgrid.New(-2, -1, 3): Piece{creature: QueenBee, colour: Black},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):   {Piece{creature: SoldierAnt, colour: Black}},
+				hexgrid.MustNew(0, -1, 1):  {Piece{creature: Beetle, colour: Black}},
+				hexgrid.MustNew(0, -2, 2):  {Piece{creature: QueenBee, colour: White}},
+				hexgrid.MustNew(-1, 0, 1):  {Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(-2, 0, 2):  {Piece{creature: Grasshopper, colour: White}},
+				hexgrid.MustNew(-2, -1, 3): {Piece{creature: QueenBee, colour: Black}},
 			},
 		},
 		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
 			Black: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(0, 0, 0): []hexgrid.Hex{
-					hexgrid.New(-1, 1, 0), hexgrid.New(-2, 1, 1), hexgrid.New(-3, 1, 2), hexgrid.New(-3, 0, 3), hexgrid.New(-3, -1, 4), hexgrid.New(-2, -2, 4),
-					hexgrid.New(-1, -2, 3), hexgrid.New(0, -3, 3), hexgrid.New(1, -3, 2), hexgrid.New(1, -2, 1), hexgrid.New(1, -1, 0),
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(-2, 1, 1), hexgrid.MustNew(-3, 1, 2), hexgrid.MustNew(-3, 0, 3), hexgrid.MustNew(-3, -1, 4), hexgrid.MustNew(-2, -2, 4),
+					hexgrid.MustNew(-1, -2, 3), hexgrid.MustNew(0, -3, 3), hexgrid.MustNew(1, -3, 2), hexgrid.MustNew(1, -2, 1), hexgrid.MustNew(1, -1, 0),
 				},
-				hexgrid.New(-2, -1, 3): []hexgrid.Hex{hexgrid.New(-3, 0, 3), hexgrid.New(-1, -1, 2)},
+				hexgrid.MustNew(-2, -1, 3): []hexgrid.Hex{hexgrid.MustNew(-3, 0, 3), hexgrid.MustNew(-1, -1, 2)},
 			},
 			White: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(0, -2, 2): []hexgrid.Hex{hexgrid.New(-1, -1, 2), hexgrid.New(1, -2, 1)},
+				hexgrid.MustNew(0, -2, 2): []hexgrid.Hex{hexgrid.MustNew(-1, -1, 2), hexgrid.MustNew(1, -2, 1)},
 			},
 		},
 	},
@@ -236,12 +238,12 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//          \__/
 	"Game 7": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):  Piece{creature: SoldierAnt, colour: Black},
-				hexgrid.New(-1, 0, 1): Piece{creature: QueenBee, colour: Black},
-				hexgrid.New(-1, 1, 0): Piece{creature: SoldierAnt, colour: White},
-				hexgrid.New(1, 0, -1): Piece{creature: Beetle, colour: Black},
-				hexgrid.New(2, 0, -2): Piece{creature: QueenBee, colour: White},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: SoldierAnt, colour: Black}},
+				hexgrid.MustNew(-1, 0, 1): {Piece{creature: QueenBee, colour: Black}},
+				hexgrid.MustNew(-1, 1, 0): {Piece{creature: SoldierAnt, colour: White}},
+				hexgrid.MustNew(1, 0, -1): {Piece{creature: Beetle, colour: Black}},
+				hexgrid.MustNew(2, 0, -2): {Piece{creature: QueenBee, colour: White}},
 			},
 			// Incomplete move options
 		},
@@ -257,20 +259,20 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//       \__/
 	"Game 8": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
+			positions: map[hexgrid.Hex][]Piece{
 
-				hexgrid.New(0, 0, 0):   Piece{creature: QueenBee, colour: Black},
-				hexgrid.New(-1, 1, 0):  Piece{creature: Grasshopper, colour: Black},
-				hexgrid.New(1, -1, 0):  Piece{creature: SoldierAnt, colour: Black},
-				hexgrid.New(2, -1, -1): Piece{creature: Spider, colour: White},
-				hexgrid.New(2, 0, -2):  Piece{creature: Beetle, colour: White},
-				hexgrid.New(1, 1, -2):  Piece{creature: Beetle, colour: Black},
+				hexgrid.MustNew(0, 0, 0):   {Piece{creature: QueenBee, colour: Black}},
+				hexgrid.MustNew(-1, 1, 0):  {Piece{creature: Grasshopper, colour: Black}},
+				hexgrid.MustNew(1, -1, 0):  {Piece{creature: SoldierAnt, colour: Black}},
+				hexgrid.MustNew(2, -1, -1): {Piece{creature: Spider, colour: White}},
+				hexgrid.MustNew(2, 0, -2):  {Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(1, 1, -2):  {Piece{creature: Beetle, colour: Black}},
 			},
 		},
 		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
 			Black: map[hexgrid.Hex][]hexgrid.Hex{
-				hexgrid.New(-1, 1, 0): []hexgrid.Hex{hexgrid.New(2, -2, 0)},
-				hexgrid.New(1, 1, -2): []hexgrid.Hex{hexgrid.New(1, 0, -1), hexgrid.New(2, 0, -2), hexgrid.New(2, 1, -3)},
+				hexgrid.MustNew(-1, 1, 0): []hexgrid.Hex{hexgrid.MustNew(2, -2, 0)},
+				hexgrid.MustNew(1, 1, -2): []hexgrid.Hex{hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(2, 0, -2), hexgrid.MustNew(2, 1, -3)},
 			},
 			White: map[hexgrid.Hex][]hexgrid.Hex{},
 		},
@@ -285,17 +287,208 @@ var sampleGames map[string]testCaseGame = map[string]testCaseGame{
 	//  \__/  \__/
 	"Game 9": {
 		game: Game{
-			positions: map[hexgrid.Hex]Piece{
-				hexgrid.New(0, 0, 0):  Piece{creature: Spider},
-				hexgrid.New(-1, 1, 0): Piece{creature: Beetle},
-				hexgrid.New(-1, 0, 1): Piece{creature: Beetle},
-				hexgrid.New(0, -1, 1): Piece{creature: Beetle},
-				hexgrid.New(1, -1, 0): Piece{creature: Beetle},
-				hexgrid.New(1, 0, -1): Piece{creature: Beetle},
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Spider}},
+				hexgrid.MustNew(-1, 1, 0): {Piece{creature: Beetle}},
+				hexgrid.MustNew(-1, 0, 1): {Piece{creature: Beetle}},
+				hexgrid.MustNew(0, -1, 1): {Piece{creature: Beetle}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Beetle}},
+				hexgrid.MustNew(1, 0, -1): {Piece{creature: Beetle}},
 			},
 		},
 		// Incomplete move options
 	},
+
+	// Beetle on top of the hive pins the spider beneath it
+	//     __
+	//    /QB\
+	//    \__/
+	//    /*B\
+	//    \__/
+	//    /QB\
+	//    \__/
+	"Game 10": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Spider, colour: Black}, Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(0, -1, 1): {Piece{creature: QueenBee, colour: Black}},
+				hexgrid.MustNew(0, 1, -1): {Piece{creature: QueenBee, colour: White}},
+			},
+		},
+		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
+			Black: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, -1, 1): []hexgrid.Hex{hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(-1, 0, 1)},
+			},
+			White: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(0, 1, -1), hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(-1, 0, 1),
+				},
+				hexgrid.MustNew(0, 1, -1): []hexgrid.Hex{hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(-1, 1, 0)},
+			},
+		},
+		placements: map[int][]hexgrid.Hex{
+			Black: []hexgrid.Hex{hexgrid.MustNew(0, -2, 2), hexgrid.MustNew(1, -2, 1), hexgrid.MustNew(-1, -1, 2)},
+			White: []hexgrid.Hex{
+				hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(1, 1, -2), hexgrid.MustNew(0, 2, -2), hexgrid.MustNew(-1, 2, -1),
+			},
+		},
+	},
+
+	// Mosquito copies the moves of adjacent creatures
+	//     __
+	//  __/ G\
+	// /*M\__/
+	// \__/QB\
+	//    \__/
+	"Game 11": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Mosquito, colour: Black}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Grasshopper, colour: White}},
+				hexgrid.MustNew(1, 0, -1): {Piece{creature: QueenBee, colour: Black}},
+			},
+		},
+		// Incomplete move options
+		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
+			Black: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(2, -2, 0), hexgrid.MustNew(2, 0, -2), hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(0, 1, -1),
+				},
+			},
+		},
+	},
+
+	// Mosquito touching only another Mosquito cannot move
+	//     __
+	//  __/ M\
+	// /*M\__/
+	// \__/
+	"Game 12": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Mosquito, colour: Black}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Mosquito, colour: White}},
+			},
+		},
+		// Incomplete move options
+	},
+
+	// Mosquito on top of the hive moves as a Beetle
+	//     __
+	//  __/ G\
+	// /*M\__/
+	// \__/
+	"Game 13": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: QueenBee, colour: White}, Piece{creature: Mosquito, colour: Black}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Grasshopper, colour: White}},
+			},
+		},
+		// Incomplete move options
+		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
+			Black: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(0, 1, -1), hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(-1, 0, 1),
+				},
+			},
+		},
+	},
+
+	// Ladybug moves two steps on top of the hive and one step down
+	//        __
+	//     __/ G\
+	//  __/ S\__/
+	// /*L\__/
+	// \__/
+	"Game 14": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Ladybug, colour: White}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Spider, colour: Black}},
+				hexgrid.MustNew(2, -2, 0): {Piece{creature: Grasshopper, colour: Black}},
+			},
+		},
+		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
+			White: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(2, -3, 1), hexgrid.MustNew(3, -3, 0), hexgrid.MustNew(3, -2, -1), hexgrid.MustNew(2, -1, -1), hexgrid.MustNew(1, -2, 1),
+				},
+			},
+		},
+	},
+
+	// Ladybug cannot climb down between two stacks taller than the one it stands on
+	//        __
+	//     __/ G\__
+	//  __/ B\__/ B\
+	// /*L\__/  \__/
+	// \__/
+	"Game 15": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):   {Piece{creature: Ladybug, colour: White}},
+				hexgrid.MustNew(1, -1, 0):  {Piece{creature: Spider, colour: White}, Piece{creature: Beetle, colour: Black}},
+				hexgrid.MustNew(2, -2, 0):  {Piece{creature: Grasshopper, colour: Black}},
+				hexgrid.MustNew(3, -2, -1): {Piece{creature: QueenBee, colour: Black}, Piece{creature: Beetle, colour: White}},
+			},
+		},
+		// Incomplete move options
+		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
+			White: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{hexgrid.MustNew(2, -3, 1), hexgrid.MustNew(3, -3, 0), hexgrid.MustNew(1, -2, 1)},
+			},
+		},
+	},
+
+	// Beetle cannot climb between two stacks taller than its destination
+	//  __
+	// / B\__
+	// \__/ S\
+	// /*B\__/
+	// \__/ B\
+	//    \__/
+	"Game 16": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(0, -1, 1): {Piece{creature: QueenBee, colour: Black}, Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(1, -1, 0): {Piece{creature: Spider, colour: Black}},
+				hexgrid.MustNew(1, 0, -1): {Piece{creature: QueenBee, colour: White}, Piece{creature: Beetle, colour: Black}},
+			},
+		},
+		// Incomplete move options
+		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
+			White: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(0, 1, -1), hexgrid.MustNew(-1, 0, 1),
+				},
+			},
+		},
+	},
+
+	// Beetle cannot climb down between two stacks taller than its origin
+	//     __
+	//  __/*B\__
+	// / B\__/ B\
+	// \__/  \__/
+	"Game 17": {
+		game: Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: Spider, colour: White}, Piece{creature: Beetle, colour: Black}},
+				hexgrid.MustNew(1, 0, -1): {Piece{creature: QueenBee, colour: White}, Piece{creature: Beetle, colour: White}},
+				hexgrid.MustNew(-1, 1, 0): {Piece{creature: QueenBee, colour: Black}, Piece{creature: Beetle, colour: White}},
+			},
+		},
+		// Incomplete move options
+		moves: map[int]map[hexgrid.Hex][]hexgrid.Hex{
+			Black: map[hexgrid.Hex][]hexgrid.Hex{
+				hexgrid.MustNew(0, 0, 0): []hexgrid.Hex{
+					hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(-1, 0, 1),
+				},
+			},
+		},
+	},
 }
 
 // TestGetAvailableMoves performs functional tests for getting the available moves for a piece in a game
@@ -310,10 +503,21 @@ func TestGetAvailableMoves(t *testing.T) {
 		"One hive no split":          sampleGames["Game 7"],
 		"One hive always linked":     sampleGames["Game 8"],
 		"Trapped spider":             sampleGames["Game 9"],
+		"Beetle on top of hive":      sampleGames["Game 10"],
+		"Mosquito":                   sampleGames["Game 11"],
+		"Mosquito touching Mosquito": sampleGames["Game 12"],
+		"Mosquito on top of hive":    sampleGames["Game 13"],
+		"Ladybug":                    sampleGames["Game 14"],
+		"Ladybug gated":              sampleGames["Game 15"],
+		"Beetle climbing gate":       sampleGames["Game 16"],
+		"Beetle descending gate":     sampleGames["Game 17"],
 	}
 	for name, tc := range tests {
 		t.Run(name, func(t *testing.T) {
-			got := GetAvailableMoves(hexgrid.New(0, 0, 0), tc.game)
+			got, err := GetAvailableMoves(hexgrid.MustNew(0, 0, 0), tc.game)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
 			want := tc.zeroPosMoves()
 			if !hexSlicesAreEqual(got, want) {
 				t.Errorf("Got %v, want %v", got, want)
@@ -358,15 +562,19 @@ var colourNames map[int]string = map[int]string{
 
 func TestGetAllAvailableMoves(t *testing.T) {
 	tests := map[string]testCaseGame{
-		"Game 3 moves ": sampleGames["Game 3"],
-		"Game 5 moves ": sampleGames["Game 5"],
-		"Game 6 moves ": sampleGames["Game 6"],
-		"Game 8 moves ": sampleGames["Game 8"],
+		"Game 3 moves ":  sampleGames["Game 3"],
+		"Game 5 moves ":  sampleGames["Game 5"],
+		"Game 6 moves ":  sampleGames["Game 6"],
+		"Game 8 moves ":  sampleGames["Game 8"],
+		"Game 10 moves ": sampleGames["Game 10"],
 	}
 	for name, tc := range tests {
 		for player := 0; player < MaxPlayers; player++ {
 			t.Run(name+colourNames[player], func(t *testing.T) {
-				got := GetAllAvailableMoves(tc.game, player)
+				got, err := GetAllAvailableMoves(tc.game, player)
+				if err != nil {
+					t.Fatalf("Unexpected error %v", err)
+				}
 				want := tc.moves[player]
 				if !hexDictsAreEqual(got, want) {
 					t.Errorf("Got %v, want %v", got, want)
@@ -378,7 +586,8 @@ func TestGetAllAvailableMoves(t *testing.T) {
 
 func TestGetPlacements(t *testing.T) {
 	tests := map[string]testCaseGame{
-		"Game 3 moves ": sampleGames["Game 3"],
+		"Game 3 moves ":  sampleGames["Game 3"],
+		"Game 10 moves ": sampleGames["Game 10"],
 	}
 	for name, tc := range tests {
 		for player := 0; player < MaxPlayers; player++ {
@@ -392,3 +601,107 @@ func TestGetPlacements(t *testing.T) {
 		}
 	}
 }
+
+func TestGetOpeningPlacements(t *testing.T) {
+	tests := map[string]struct {
+		game Game
+		want []hexgrid.Hex
+	}{
+		"First piece": {
+			game: Game{positions: map[hexgrid.Hex][]Piece{}},
+			want: []hexgrid.Hex{hexgrid.MustNew(0, 0, 0)},
+		},
+		"Second piece": {
+			game: Game{
+				positions: map[hexgrid.Hex][]Piece{
+					hexgrid.MustNew(0, 0, 0): {Piece{creature: Spider, colour: White}},
+				},
+			},
+			want: []hexgrid.Hex{
+				hexgrid.MustNew(0, -1, 1), hexgrid.MustNew(1, -1, 0), hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(0, 1, -1), hexgrid.MustNew(-1, 1, 0), hexgrid.MustNew(-1, 0, 1),
+			},
+		},
+		"Third piece": {
+			game: Game{
+				positions: map[hexgrid.Hex][]Piece{
+					hexgrid.MustNew(0, 0, 0):  {Piece{creature: Spider, colour: White}},
+					hexgrid.MustNew(0, -1, 1): {Piece{creature: Spider, colour: Black}},
+				},
+			},
+			want: []hexgrid.Hex{hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(0, 1, -1), hexgrid.MustNew(-1, 1, 0)},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := GetPlacements(tc.game, White)
+			if !hexSlicesAreEqual(got, tc.want) {
+				t.Errorf("Got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGetAvailableMovesErrors(t *testing.T) {
+	g := Game{
+		positions: map[hexgrid.Hex][]Piece{
+			hexgrid.MustNew(0, 0, 0): {Piece{creature: -1, colour: White}},
+		},
+	}
+	tests := map[string]struct {
+		h    hexgrid.Hex
+		want error
+	}{
+		"No piece":         {h: hexgrid.MustNew(1, 0, -1), want: ErrNoPiece},
+		"Unknown creature": {h: hexgrid.MustNew(0, 0, 0), want: ErrUnknownCreature},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := GetAvailableMoves(tc.h, g); !errors.Is(err, tc.want) {
+				t.Errorf("Got error %v, want %v", err, tc.want)
+			}
+		})
+	}
+
+	if _, err := GetAllAvailableMoves(g, White); err != nil {
+		t.Errorf("Got error %v for a player without a Queen Bee, want nil", err)
+	}
+	g.positions[hexgrid.MustNew(1, 0, -1)] = []Piece{{creature: QueenBee, colour: White}}
+	if _, err := GetAllAvailableMoves(g, White); !errors.Is(err, ErrUnknownCreature) {
+		t.Errorf("Got error %v, want %v", err, ErrUnknownCreature)
+	}
+}
+
+func TestGetThrowsConcurrently(t *testing.T) {
+	//  __
+	// /bQ\__
+	// \__/wQ\
+	// /bP\__/
+	// \__/
+	g, err := NewBuilder(Options{Expansions: []int{Pillbug}}).
+		Add(hexgrid.MustNew(0, 0, 0), NewPiece(QueenBee, Black, 1)).
+		Add(hexgrid.MustNew(0, 1, -1), NewPiece(Pillbug, Black, 1)).
+		Add(hexgrid.MustNew(1, 0, -1), NewPiece(QueenBee, White, 1)).
+		SetToMove(Black).
+		SetMoveCount(3).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := len(g.getThrows(Black))
+	if want == 0 {
+		t.Fatalf("Got no throws, want some")
+	}
+
+	// Finding moves must not change the game so it can be shared between goroutines
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if got := len(g.getThrows(Black)); got != want {
+				t.Errorf("Got %d throws, want %d", got, want)
+			}
+		}()
+	}
+	wg.Wait()
+}
@@ -0,0 +1,296 @@
+package hive
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// ErrInvalidJSON is returned when decoding JSON that does not describe a valid value
+var ErrInvalidJSON = errors.New("invalid JSON encoding")
+
+// Names used in JSON encodings.
+// Creatures are identified by their notation letter, such as "Q" for the Queen Bee.
+var (
+	colourJSONNames = [MaxPlayers]string{
+		Black: "black",
+		White: "white",
+	}
+	moveKindJSONNames = map[int]string{
+		Placement: "placement",
+		Movement:  "movement",
+		Pass:      "pass",
+	}
+)
+
+// pieceJSON is the JSON encoding of a Piece, such as {"creature":"S","colour":"white","number":1}
+type pieceJSON struct {
+	Creature string `json:"creature"`
+	Colour   string `json:"colour"`
+	Number   int    `json:"number"`
+}
+
+// moveJSON is the JSON encoding of a Move.
+// Only the fields used by the kind of move are included, for example
+// {"kind":"placement","creature":"Q","to":{"q":0,"r":0,"s":0}}.
+type moveJSON struct {
+	Kind     string       `json:"kind"`
+	Creature string       `json:"creature,omitempty"`
+	From     *hexgrid.Hex `json:"from,omitempty"`
+	To       *hexgrid.Hex `json:"to,omitempty"`
+	Ability  bool         `json:"ability,omitempty"`
+	Via      *hexgrid.Hex `json:"via,omitempty"`
+}
+
+// stackJSON is the JSON encoding of the pieces on a hex, bottom to top
+type stackJSON struct {
+	Hex    hexgrid.Hex `json:"hex"`
+	Pieces []Piece     `json:"pieces"`
+}
+
+// gameJSON is the JSON encoding of a Game.
+// Reserves map colours to the count of each creature left to place, leaving out creatures with none left.
+// The result is informational and checked against the position when decoding. To keep encoding cheap it only
+// reflects surrounded Queen Bees, so a game drawn because neither player can move is shown as in progress.
+type gameJSON struct {
+	GameType         string                    `json:"gameType"`
+	NoQueenFirstTurn bool                      `json:"noQueenFirstTurn"`
+	Stacks           []stackJSON               `json:"stacks"`
+	ToMove           string                    `json:"toMove"`
+	MoveCount        int                       `json:"moveCount"`
+	LastMoved        *hexgrid.Hex              `json:"lastMoved"`
+	Reserves         map[string]map[string]int `json:"reserves"`
+	Result           string                    `json:"result,omitempty"`
+}
+
+// creatureJSONName returns the notation letter of a creature
+func creatureJSONName(creature int) (string, error) {
+	ct, ok := GetCreatureType(creature)
+	if !ok {
+		return "", fmt.Errorf("%w: %d", ErrUnknownCreature, creature)
+	}
+	return string(ct.Letter), nil
+}
+
+// parseCreatureJSONName returns the creature with a notation letter
+func parseCreatureJSONName(s string) (int, error) {
+	for _, creature := range GetCreatures() {
+		if ct, _ := GetCreatureType(creature); string(ct.Letter) == s {
+			return creature, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: %q", ErrUnknownCreature, s)
+}
+
+// parseColourJSONName returns the colour with a JSON name
+func parseColourJSONName(s string) (int, error) {
+	for colour, name := range colourJSONNames {
+		if name == s {
+			return colour, nil
+		}
+	}
+	return 0, fmt.Errorf("%w: unknown colour %q", ErrInvalidJSON, s)
+}
+
+// MarshalJSON encodes a Piece as an object with its creature letter, colour and number
+func (p Piece) MarshalJSON() ([]byte, error) {
+	creature, err := creatureJSONName(p.creature)
+	if err != nil {
+		return nil, err
+	}
+	if p.colour < 0 || p.colour >= MaxPlayers {
+		return nil, fmt.Errorf("%w: unknown colour %d", ErrInvalidJSON, p.colour)
+	}
+	return json.Marshal(pieceJSON{Creature: creature, Colour: colourJSONNames[p.colour], Number: p.number})
+}
+
+// UnmarshalJSON decodes a Piece, checking that the creature is registered and the number is in range
+func (p *Piece) UnmarshalJSON(data []byte) error {
+	var v pieceJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	creature, err := parseCreatureJSONName(v.Creature)
+	if err != nil {
+		return err
+	}
+	colour, err := parseColourJSONName(v.Colour)
+	if err != nil {
+		return err
+	}
+	ct, _ := GetCreatureType(creature)
+	if v.Number < 1 || v.Number > ct.Count {
+		return fmt.Errorf("%w: piece number %d out of range for creature %q", ErrInvalidJSON, v.Number, v.Creature)
+	}
+	*p = Piece{creature: creature, colour: colour, number: v.Number}
+	return nil
+}
+
+// MarshalJSON encodes a Move as an object with its kind and the fields used by that kind
+func (m Move) MarshalJSON() ([]byte, error) {
+	kind, ok := moveKindJSONNames[m.Kind]
+	if !ok {
+		return nil, fmt.Errorf("%w: unknown move kind %d", ErrInvalidJSON, m.Kind)
+	}
+	v := moveJSON{Kind: kind}
+	switch m.Kind {
+	case Placement:
+		creature, err := creatureJSONName(m.Creature)
+		if err != nil {
+			return nil, err
+		}
+		v.Creature, v.To = creature, &m.To
+	case Movement:
+		v.From, v.To = &m.From, &m.To
+		if m.Ability {
+			v.Ability, v.Via = true, &m.Via
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a Move, checking that the fields needed by its kind are present
+func (m *Move) UnmarshalJSON(data []byte) error {
+	var v moveJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	kind := -1
+	for k, name := range moveKindJSONNames {
+		if name == v.Kind {
+			kind = k
+		}
+	}
+
+	move := Move{Kind: kind}
+	switch kind {
+	case Placement:
+		if v.To == nil || v.From != nil || v.Ability || v.Via != nil {
+			return fmt.Errorf("%w: placement needs only a creature and destination", ErrInvalidJSON)
+		}
+		creature, err := parseCreatureJSONName(v.Creature)
+		if err != nil {
+			return err
+		}
+		move.Creature, move.To = creature, *v.To
+	case Movement:
+		if v.From == nil || v.To == nil || v.Creature != "" || v.Ability != (v.Via != nil) {
+			return fmt.Errorf("%w: movement needs an origin and destination, and a via hex only when using an ability", ErrInvalidJSON)
+		}
+		move.From, move.To = *v.From, *v.To
+		if v.Ability {
+			move.Ability, move.Via = true, *v.Via
+		}
+	case Pass:
+		if v != (moveJSON{Kind: v.Kind}) {
+			return fmt.Errorf("%w: pass has no other fields", ErrInvalidJSON)
+		}
+	default:
+		return fmt.Errorf("%w: unknown move kind %q", ErrInvalidJSON, v.Kind)
+	}
+	*m = move
+	return nil
+}
+
+// MarshalJSON encodes the position of a Game along with its rules and result.
+// The move history is not included.
+func (g *Game) MarshalJSON() ([]byte, error) {
+	v := gameJSON{
+		GameType:         FormatGameType(g.options),
+		NoQueenFirstTurn: g.options.NoQueenFirstTurn,
+		Stacks:           []stackJSON{},
+		ToMove:           colourJSONNames[g.toMove],
+		MoveCount:        g.moveCount,
+		Reserves:         map[string]map[string]int{},
+		Result:           resultNames[g.queenResult()],
+	}
+	for _, h := range g.Occupied() {
+		v.Stacks = append(v.Stacks, stackJSON{Hex: h, Pieces: g.Stack(h)})
+	}
+	if h, ok := g.LastMoved(); ok {
+		v.LastMoved = &h
+	}
+	for colour, name := range colourJSONNames {
+		v.Reserves[name] = map[string]int{}
+		for creature, count := range g.Reserve(colour) {
+			letter, err := creatureJSONName(creature)
+			if err != nil {
+				return nil, err
+			}
+			v.Reserves[name][letter] = count
+		}
+	}
+	return json.Marshal(v)
+}
+
+// UnmarshalJSON decodes a Game, validating the position as a Builder does.
+// The reserves must match the pieces left over from the board, and the result, if given, must match the Queen Bees
+// surrounded on the board.
+func (g *Game) UnmarshalJSON(data []byte) error {
+	var v gameJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+
+	opts, err := ParseGameType(v.GameType)
+	if err != nil {
+		return err
+	}
+	opts.NoQueenFirstTurn = v.NoQueenFirstTurn
+	b := NewBuilder(opts)
+
+	seen := map[hexgrid.Hex]struct{}{}
+	for _, stack := range v.Stacks {
+		if _, ok := seen[stack.Hex]; ok {
+			return fmt.Errorf("%w: hex %v listed more than once", ErrInvalidJSON, stack.Hex)
+		}
+		seen[stack.Hex] = struct{}{}
+		if len(stack.Pieces) == 0 {
+			return fmt.Errorf("%w: empty stack at hex %v", ErrInvalidJSON, stack.Hex)
+		}
+		for _, p := range stack.Pieces {
+			b.Add(stack.Hex, p)
+		}
+	}
+
+	toMove, err := parseColourJSONName(v.ToMove)
+	if err != nil {
+		return err
+	}
+	b.SetToMove(toMove).SetMoveCount(v.MoveCount)
+	if v.LastMoved != nil {
+		b.SetLastMoved(*v.LastMoved)
+	}
+
+	built, err := b.Build()
+	if err != nil {
+		return err
+	}
+
+	for colour, name := range colourJSONNames {
+		reserve := built.Reserve(colour)
+		if len(v.Reserves[name]) != len(reserve) {
+			return fmt.Errorf("%w: %s reserve does not match the board", ErrInvalidPosition, name)
+		}
+		for letter, count := range v.Reserves[name] {
+			creature, err := parseCreatureJSONName(letter)
+			if err != nil {
+				return err
+			}
+			if reserve[creature] != count {
+				return fmt.Errorf("%w: %s reserve does not match the board", ErrInvalidPosition, name)
+			}
+		}
+	}
+
+	if v.Result != "" {
+		if v.Result != resultNames[built.queenResult()] {
+			return fmt.Errorf("%w: result %q does not match the position", ErrInvalidPosition, v.Result)
+		}
+	}
+
+	*g = *built
+	return nil
+}
@@ -0,0 +1,294 @@
+package hive
+
+import (
+	"encoding/json"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+func TestPieceJSON(t *testing.T) {
+	p := Piece{creature: SoldierAnt, colour: Black, number: 2}
+	data, err := json.Marshal(p)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if want := `{"creature":"A","colour":"black","number":2}`; string(data) != want {
+		t.Errorf("Got %s, want %s", data, want)
+	}
+
+	tests := map[string]struct {
+		data string
+		err  error
+	}{
+		"Valid": {
+			data: `{"creature":"A","colour":"black","number":2}`,
+		},
+		"Unknown creature": {
+			data: `{"creature":"Z","colour":"black","number":2}`,
+			err:  ErrUnknownCreature,
+		},
+		"Unknown colour": {
+			data: `{"creature":"A","colour":"red","number":2}`,
+			err:  ErrInvalidJSON,
+		},
+		"Number out of range": {
+			data: `{"creature":"A","colour":"black","number":4}`,
+			err:  ErrInvalidJSON,
+		},
+		"Missing number": {
+			data: `{"creature":"A","colour":"black"}`,
+			err:  ErrInvalidJSON,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var got Piece
+			err := json.Unmarshal([]byte(test.data), &got)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Got error %v, want %v", err, test.err)
+			}
+			if err == nil && got != p {
+				t.Errorf("Got %+v, want %+v", got, p)
+			}
+		})
+	}
+}
+
+func TestMoveJSON(t *testing.T) {
+	tests := map[string]struct {
+		move Move
+		data string
+	}{
+		"Placement": {
+			move: Move{Kind: Placement, Creature: Pillbug, To: hexgrid.MustNew(1, -1, 0)},
+			data: `{"kind":"placement","creature":"P","to":{"q":1,"r":-1,"s":0}}`,
+		},
+		"Movement": {
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(0, 1, -1)},
+			data: `{"kind":"movement","from":{"q":0,"r":0,"s":0},"to":{"q":0,"r":1,"s":-1}}`,
+		},
+		"Throw": {
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(2, -1, -1), Ability: true, Via: hexgrid.MustNew(1, -1, 0)},
+			data: `{"kind":"movement","from":{"q":0,"r":0,"s":0},"to":{"q":2,"r":-1,"s":-1},"ability":true,"via":{"q":1,"r":-1,"s":0}}`,
+		},
+		"Pass": {
+			move: Move{Kind: Pass},
+			data: `{"kind":"pass"}`,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			data, err := json.Marshal(test.move)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if string(data) != test.data {
+				t.Errorf("Got %s, want %s", data, test.data)
+			}
+			var got Move
+			if err := json.Unmarshal(data, &got); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if got != test.move {
+				t.Errorf("Got %+v, want %+v", got, test.move)
+			}
+		})
+	}
+}
+
+func TestMoveJSONErrors(t *testing.T) {
+	tests := map[string]struct {
+		data string
+		err  error
+	}{
+		"Unknown kind": {
+			data: `{"kind":"jump"}`,
+			err:  ErrInvalidJSON,
+		},
+		"Placement without destination": {
+			data: `{"kind":"placement","creature":"Q"}`,
+			err:  ErrInvalidJSON,
+		},
+		"Placement of unknown creature": {
+			data: `{"kind":"placement","creature":"Z","to":{"q":0,"r":0,"s":0}}`,
+			err:  ErrUnknownCreature,
+		},
+		"Movement without origin": {
+			data: `{"kind":"movement","to":{"q":0,"r":0,"s":0}}`,
+			err:  ErrInvalidJSON,
+		},
+		"Ability without via": {
+			data: `{"kind":"movement","from":{"q":0,"r":0,"s":0},"to":{"q":0,"r":1,"s":-1},"ability":true}`,
+			err:  ErrInvalidJSON,
+		},
+		"Pass with destination": {
+			data: `{"kind":"pass","to":{"q":0,"r":0,"s":0}}`,
+			err:  ErrInvalidJSON,
+		},
+		"Invalid hex": {
+			data: `{"kind":"movement","from":{"q":0,"r":0,"s":0},"to":{"q":0,"r":1,"s":1}}`,
+			err:  hexgrid.ErrInvalidCoordinates,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var m Move
+			if err := json.Unmarshal([]byte(test.data), &m); !errors.Is(err, test.err) {
+				t.Errorf("Got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
+
+func TestLegalMovesJSON(t *testing.T) {
+	g := mustNewGame(t, Options{Expansions: []int{Pillbug}})
+	for i, m := range historyTestMoves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	moves := mustGetLegalMoves(t, g)
+
+	data, err := json.Marshal(moves)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	var got []Move
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !reflect.DeepEqual(got, moves) {
+		t.Errorf("Got %+v, want %+v", got, moves)
+	}
+}
+
+func TestGameJSON(t *testing.T) {
+	g := mustNewGame(t, Options{NoQueenFirstTurn: true, Expansions: []int{Ladybug}})
+	for i, m := range []Move{
+		{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, 0, 0)},
+		{Kind: Placement, Creature: Ladybug, To: hexgrid.MustNew(0, -1, 1)},
+	} {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := `{"gameType":"Base+L","noQueenFirstTurn":true,` +
+		`"stacks":[{"hex":{"q":0,"r":-1,"s":1},"pieces":[{"creature":"L","colour":"black","number":1}]},` +
+		`{"hex":{"q":0,"r":0,"s":0},"pieces":[{"creature":"S","colour":"white","number":1}]}],` +
+		`"toMove":"white","moveCount":2,"lastMoved":{"q":0,"r":-1,"s":1},` +
+		`"reserves":{"black":{"A":3,"B":2,"G":3,"Q":1,"S":2},"white":{"A":3,"B":2,"G":3,"L":1,"Q":1,"S":1}},` +
+		`"result":"InProgress"}`
+	if string(data) != want {
+		t.Errorf("Got %s, want %s", data, want)
+	}
+
+	var got Game
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !sameState(&got, g) || !reflect.DeepEqual(got.Options(), g.Options()) {
+		t.Errorf("Decoded game does not match")
+	}
+}
+
+func TestGameJSONResult(t *testing.T) {
+	//     __
+	//  __/wQ\__
+	// /wG\__/wS\
+	// \__/bQ\__/
+	// /wG\__/wS\
+	// \__/wG\__/
+	//    \__/
+	b := NewBuilder(Options{}).Add(hexgrid.MustNew(0, 0, 0), NewPiece(QueenBee, Black, 1))
+	for i, creature := range []int{QueenBee, Spider, Spider, Grasshopper, Grasshopper, Grasshopper} {
+		b.Add(hexgrid.HexDirectionVectors[i], NewPiece(creature, White, 0))
+	}
+	g, err := b.SetToMove(Black).SetMoveCount(7).Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	data, err := json.Marshal(g)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !strings.HasSuffix(string(data), `"result":"WhiteWins"}`) {
+		t.Errorf("Got %s, want a win for White", data)
+	}
+	var got Game
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !sameState(&got, g) {
+		t.Errorf("Decoded game does not match")
+	}
+}
+
+func TestGameJSONErrors(t *testing.T) {
+	valid := `"toMove":"black","moveCount":1,"lastMoved":null,` +
+		`"reserves":{"black":{"A":3,"B":2,"G":3,"Q":1,"S":2},"white":{"A":3,"B":2,"G":3,"S":2}}`
+	stacks := `"stacks":[{"hex":{"q":0,"r":0,"s":0},"pieces":[{"creature":"Q","colour":"white","number":1}]}]`
+
+	tests := map[string]struct {
+		data string
+		err  error
+	}{
+		"Valid": {
+			data: `{"gameType":"Base",` + stacks + `,` + valid + `}`,
+		},
+		"Unknown game type": {
+			data: `{"gameType":"Other",` + stacks + `,` + valid + `}`,
+			err:  ErrInvalidNotation,
+		},
+		"Duplicate hex": {
+			data: `{"gameType":"Base","stacks":[` +
+				`{"hex":{"q":0,"r":0,"s":0},"pieces":[{"creature":"Q","colour":"white","number":1}]},` +
+				`{"hex":{"q":0,"r":0,"s":0},"pieces":[{"creature":"Q","colour":"black","number":1}]}],` + valid + `}`,
+			err: ErrInvalidJSON,
+		},
+		"Empty stack": {
+			data: `{"gameType":"Base","stacks":[{"hex":{"q":0,"r":0,"s":0},"pieces":[]}],` + valid + `}`,
+			err:  ErrInvalidJSON,
+		},
+		"Disconnected": {
+			data: `{"gameType":"Base","stacks":[` +
+				`{"hex":{"q":0,"r":0,"s":0},"pieces":[{"creature":"Q","colour":"white","number":1}]},` +
+				`{"hex":{"q":3,"r":0,"s":-3},"pieces":[{"creature":"S","colour":"black","number":1}]}],` + valid + `}`,
+			err: ErrInvalidPosition,
+		},
+		"Unknown colour to move": {
+			data: `{"gameType":"Base",` + stacks + `,"toMove":"red","moveCount":1,"reserves":{}}`,
+			err:  ErrInvalidJSON,
+		},
+		"Reserves do not match": {
+			data: `{"gameType":"Base",` + stacks + `,"toMove":"black","moveCount":1,` +
+				`"reserves":{"black":{"A":3,"B":2,"G":3,"Q":1,"S":2},"white":{"A":3,"B":2,"G":3,"Q":1,"S":2}}}`,
+			err: ErrInvalidPosition,
+		},
+		"Result does not match": {
+			data: `{"gameType":"Base",` + stacks + `,` + valid + `,"result":"Draw"}`,
+			err:  ErrInvalidPosition,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var g Game
+			if err := json.Unmarshal([]byte(test.data), &g); !errors.Is(err, test.err) {
+				t.Errorf("Got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
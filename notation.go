@@ -0,0 +1,254 @@
+package hive
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// ErrInvalidNotation is returned when a piece name or move string cannot be understood
+var ErrInvalidNotation = errors.New("invalid notation")
+
+// PassNotation is the move string for a pass
+const PassNotation = "pass"
+
+// colourLetters are the letters used for each colour in piece names
+var colourLetters = [MaxPlayers]byte{
+	Black: 'b',
+	White: 'w',
+}
+
+// Direction markers placing a piece relative to a reference piece.
+// Indexed by the direction from the reference piece in hexgrid.HexDirectionVectors, a marker is written
+// before the reference for the directions to its left and after it for the directions to its right.
+var (
+	directionPrefixes = [hexgrid.MaxDirections]string{hexgrid.Down: "/", hexgrid.DownLeft: "-", hexgrid.UpLeft: `\`}
+	directionSuffixes = [hexgrid.MaxDirections]string{hexgrid.Up: "/", hexgrid.UpRight: "-", hexgrid.DownRight: `\`}
+)
+
+// directionMarkers are all the characters used as direction markers
+const directionMarkers = `/-\`
+
+// FormatPiece returns the name of a piece such as wS1.
+// The number is omitted for creatures with a single piece in the set.
+func FormatPiece(p Piece) string {
+	ct, _ := GetCreatureType(p.creature)
+	name := string([]byte{colourLetters[p.colour], ct.Letter})
+	if ct.Count > 1 {
+		name += strconv.Itoa(p.number)
+	}
+	return name
+}
+
+// ParsePiece returns the piece named by a string such as wS1
+func ParsePiece(s string) (Piece, error) {
+	if len(s) < 2 {
+		return Piece{}, fmt.Errorf("%w: piece %q", ErrInvalidNotation, s)
+	}
+
+	colour := -1
+	for c, letter := range colourLetters {
+		if s[0] == letter {
+			colour = c
+		}
+	}
+	if colour < 0 {
+		return Piece{}, fmt.Errorf("%w: unknown colour in piece %q", ErrInvalidNotation, s)
+	}
+
+	for _, creature := range GetCreatures() {
+		ct, _ := GetCreatureType(creature)
+		if ct.Letter != s[1] {
+			continue
+		}
+		number := 1
+		if ct.Count > 1 {
+			n, err := parseNumber(s[2:])
+			if err != nil || n < 1 || n > ct.Count {
+				return Piece{}, fmt.Errorf("%w: invalid number in piece %q", ErrInvalidNotation, s)
+			}
+			number = n
+		} else if len(s) > 2 {
+			return Piece{}, fmt.Errorf("%w: unexpected number in piece %q", ErrInvalidNotation, s)
+		}
+		return Piece{creature: creature, colour: colour, number: number}, nil
+	}
+	return Piece{}, fmt.Errorf("%w: unknown creature in piece %q", ErrInvalidNotation, s)
+}
+
+// parseNumber returns the value of a piece number, which must be plain digits without a leading zero
+func parseNumber(s string) (int, error) {
+	if s == "" || s[0] == '0' {
+		return 0, ErrInvalidNotation
+	}
+	for i := 0; i < len(s); i++ {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, ErrInvalidNotation
+		}
+	}
+	return strconv.Atoi(s)
+}
+
+// FormatMove returns the move string for a move in the current position, such as "wS1 -bG2".
+// A piece climbing on to the hive is placed on the piece it climbs on to, and any other piece is placed
+// next to the first neighbour of its destination clockwise from above.
+func (g *Game) FormatMove(m Move) (string, error) {
+	var piece Piece
+	switch m.Kind {
+	case Pass:
+		return PassNotation, nil
+	case Placement:
+		if _, ok := GetCreatureType(m.Creature); !ok {
+			return "", fmt.Errorf("%w: %d", ErrUnknownCreature, m.Creature)
+		}
+		piece = Piece{creature: m.Creature, colour: g.toMove, number: g.nextNumber(m.Creature, g.toMove)}
+	case Movement:
+		p, ok := g.topPiece(m.From)
+		if !ok {
+			return "", fmt.Errorf("%w: %v", ErrNoPiece, m.From)
+		}
+		piece = p
+	default:
+		return "", fmt.Errorf("%w: unknown move kind %d", ErrInvalidNotation, m.Kind)
+	}
+	name := FormatPiece(piece)
+
+	// The first piece of the game needs no reference
+	if len(g.positions) == 0 {
+		return name, nil
+	}
+
+	if top, ok := g.topPiece(m.To); ok {
+		return name + " " + FormatPiece(top), nil
+	}
+
+	for direction, neighbour := range m.To.GetAdjacent() {
+		stack := g.positions[neighbour]
+		if m.Kind == Movement && neighbour == m.From {
+			stack = stack[:len(stack)-1]
+		}
+		if len(stack) == 0 {
+			continue
+		}
+		ref := FormatPiece(stack[len(stack)-1])
+		fromRef := (direction + hexgrid.MaxDirections/2) % hexgrid.MaxDirections
+		return name + " " + directionPrefixes[fromRef] + ref + directionSuffixes[fromRef], nil
+	}
+	return "", fmt.Errorf("%w: no piece next to destination %v", ErrIllegalMove, m.To)
+}
+
+// ParseMove returns the move described by a move string in the current position.
+// The move is not checked for legality beyond the pieces it names being where they need to be.
+func (g *Game) ParseMove(s string) (Move, error) {
+	s = strings.TrimSpace(s)
+	if strings.EqualFold(s, PassNotation) {
+		return Move{Kind: Pass}, nil
+	}
+
+	fields := strings.Fields(s)
+	if len(fields) == 0 || len(fields) > 2 {
+		return Move{}, fmt.Errorf("%w: move %q", ErrInvalidNotation, s)
+	}
+	piece, err := ParsePiece(fields[0])
+	if err != nil {
+		return Move{}, err
+	}
+
+	m := Move{Kind: Placement, Creature: piece.creature}
+	if from, height, ok := g.Find(piece); ok {
+		if height != len(g.positions[from])-1 {
+			return Move{}, fmt.Errorf("%w: %s is covered by another piece", ErrIllegalMove, fields[0])
+		}
+		m = Move{Kind: Movement, From: from}
+	} else if piece.colour != g.toMove || piece.number != g.nextNumber(piece.creature, piece.colour) {
+		return Move{}, fmt.Errorf("%w: %s cannot be placed this turn", ErrIllegalMove, fields[0])
+	}
+
+	// Only the first piece of the game may omit its position
+	if len(fields) == 1 {
+		if len(g.positions) > 0 {
+			return Move{}, fmt.Errorf("%w: missing position in move %q", ErrInvalidNotation, s)
+		}
+		m.To = hexgrid.MustNew(0, 0, 0)
+		return m, nil
+	}
+
+	// The position has at most one marker, either before or after the reference piece
+	position := fields[1]
+	direction := -1
+	for d, prefix := range directionPrefixes {
+		if prefix != "" && strings.HasPrefix(position, prefix) {
+			direction, position = d, position[len(prefix):]
+			break
+		}
+	}
+	for d, suffix := range directionSuffixes {
+		if direction < 0 && suffix != "" && strings.HasSuffix(position, suffix) {
+			direction, position = d, position[:len(position)-len(suffix)]
+			break
+		}
+	}
+	if strings.ContainsAny(position, directionMarkers) {
+		return Move{}, fmt.Errorf("%w: more than one direction in move %q", ErrInvalidNotation, s)
+	}
+
+	ref, err := ParsePiece(position)
+	if err != nil {
+		return Move{}, err
+	}
+	refHex, _, ok := g.Find(ref)
+	if !ok {
+		return Move{}, fmt.Errorf("%w: %s is not on the board", ErrIllegalMove, position)
+	}
+	m.To = refHex
+	if direction >= 0 {
+		m.To = refHex.Move(direction)
+	}
+	return m, nil
+}
+
+// BaseGameType is the game type string of a game without expansions
+const BaseGameType = "Base"
+
+// FormatGameType returns the game type string for a set of options, such as Base+MLP.
+// Expansions are listed by their letters in creature order.
+func FormatGameType(opts Options) string {
+	var letters []byte
+	for _, creature := range GetCreatures() {
+		for _, expansion := range opts.Expansions {
+			if expansion == creature {
+				ct, _ := GetCreatureType(creature)
+				letters = append(letters, ct.Letter)
+			}
+		}
+	}
+	if len(letters) == 0 {
+		return BaseGameType
+	}
+	return BaseGameType + "+" + string(letters)
+}
+
+// ParseGameType returns the options for a game type string such as Base+MLP
+func ParseGameType(s string) (Options, error) {
+	var opts Options
+	letters := strings.TrimPrefix(s, BaseGameType)
+	if !strings.HasPrefix(s, BaseGameType) || (letters != "" && !strings.HasPrefix(letters, "+")) {
+		return opts, fmt.Errorf("%w: game type %q", ErrInvalidNotation, s)
+	}
+	for _, letter := range []byte(strings.TrimPrefix(letters, "+")) {
+		found := false
+		for _, creature := range GetCreatures() {
+			if ct, _ := GetCreatureType(creature); ct.Expansion && ct.Letter == letter {
+				opts.Expansions = append(opts.Expansions, creature)
+				found = true
+			}
+		}
+		if !found {
+			return opts, fmt.Errorf("%w: unknown expansion %q in game type %q", ErrInvalidNotation, letter, s)
+		}
+	}
+	return opts, nil
+}
@@ -0,0 +1,351 @@
+package hive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+func TestPieceNotation(t *testing.T) {
+	tests := map[string]struct {
+		s     string
+		piece Piece
+		err   error
+	}{
+		"Numbered": {
+			s:     "wS1",
+			piece: Piece{creature: Spider, colour: White, number: 1},
+		},
+		"Single piece": {
+			s:     "bQ",
+			piece: Piece{creature: QueenBee, colour: Black, number: 1},
+		},
+		"Expansion": {
+			s:     "bP",
+			piece: Piece{creature: Pillbug, colour: Black, number: 1},
+		},
+		"Unknown colour": {
+			s:   "xQ",
+			err: ErrInvalidNotation,
+		},
+		"Unknown creature": {
+			s:   "wZ1",
+			err: ErrInvalidNotation,
+		},
+		"Missing number": {
+			s:   "wA",
+			err: ErrInvalidNotation,
+		},
+		"Number too large": {
+			s:   "wA4",
+			err: ErrInvalidNotation,
+		},
+		"Unexpected number": {
+			s:   "wQ1",
+			err: ErrInvalidNotation,
+		},
+		"Signed number": {
+			s:   "wS+1",
+			err: ErrInvalidNotation,
+		},
+		"Leading zero": {
+			s:   "wS01",
+			err: ErrInvalidNotation,
+		},
+		"Too short": {
+			s:   "w",
+			err: ErrInvalidNotation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			piece, err := ParsePiece(test.s)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Got error %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if piece != test.piece {
+				t.Errorf("Got %+v, want %+v", piece, test.piece)
+			}
+			if got := FormatPiece(piece); got != test.s {
+				t.Errorf("Formatted as %q, want %q", got, test.s)
+			}
+		})
+	}
+}
+
+func TestMoveNotationRoundTrip(t *testing.T) {
+	g := mustNewGame(t, Options{Expansions: []int{Mosquito, Ladybug, Pillbug}})
+
+	// Every legal move must survive formatting and parsing along a sequence of moves
+	for _, s := range []string{"wS1", "bP wS1/", "wQ /wS1", "bQ bP-", `wB1 wQ\`, "bA1 bQ/", "wB1 wQ", "bA1 -wS1"} {
+		for _, m := range mustGetLegalMoves(t, g) {
+			str, err := g.FormatMove(m)
+			if err != nil {
+				t.Fatalf("Cannot format %+v: %v", m, err)
+			}
+			got, err := g.ParseMove(str)
+			if err != nil {
+				t.Fatalf("Cannot parse %q: %v", str, err)
+			}
+			if !got.matches(m) {
+				t.Errorf("%q parsed to %+v, want %+v", str, got, m)
+			}
+		}
+
+		m, err := g.ParseMove(s)
+		if err != nil {
+			t.Fatalf("Cannot parse %q: %v", s, err)
+		}
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Cannot play %q: %v", s, err)
+		}
+	}
+}
+
+func TestFormatMove(t *testing.T) {
+	//     __
+	//  __/bQ\
+	// /wS\__/
+	// \__/
+	g, err := NewBuilder(Options{}).
+		Add(hexgrid.MustNew(0, 0, 0), NewPiece(Spider, White, 1)).
+		Add(hexgrid.MustNew(1, -1, 0), NewPiece(QueenBee, Black, 1)).
+		SetToMove(White).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	tests := map[string]struct {
+		move Move
+		want string
+		err  error
+	}{
+		"Pass": {
+			move: Move{Kind: Pass},
+			want: "pass",
+		},
+		"Placement": {
+			move: Move{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(-1, 1, 0)},
+			want: "wQ -wS1",
+		},
+		"Placement numbered": {
+			move: Move{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, 1, -1)},
+			want: "wS2 /wS1",
+		},
+		"Movement": {
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(2, -1, -1)},
+			want: `wS1 bQ\`,
+		},
+		"Movement ignores itself": {
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(1, 0, -1)},
+			want: "wS1 /bQ",
+		},
+		"Climb": {
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(1, -1, 0)},
+			want: "wS1 bQ",
+		},
+		"No piece": {
+			move: Move{Kind: Movement, From: hexgrid.MustNew(3, 0, -3), To: hexgrid.MustNew(1, 0, -1)},
+			err:  ErrNoPiece,
+		},
+		"Unknown creature": {
+			move: Move{Kind: Placement, Creature: -1, To: hexgrid.MustNew(-1, 1, 0)},
+			err:  ErrUnknownCreature,
+		},
+		"Disconnected": {
+			move: Move{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(4, 0, -4)},
+			err:  ErrIllegalMove,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got, err := g.FormatMove(test.move)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Got error %v, want %v", err, test.err)
+			}
+			if got != test.want {
+				t.Errorf("Got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseMove(t *testing.T) {
+	g := mustNewGame(t, Options{})
+	for _, s := range []string{"wS1", "bG1 -wS1"} {
+		m, err := g.ParseMove(s)
+		if err != nil {
+			t.Fatalf("Cannot parse %q: %v", s, err)
+		}
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Cannot play %q: %v", s, err)
+		}
+	}
+
+	//  __
+	// /bB\__
+	// \__/bQ\
+	//    \__/
+	covered, err := NewBuilder(Options{}).
+		Add(hexgrid.MustNew(0, 0, 0), NewPiece(QueenBee, White, 1)).
+		Add(hexgrid.MustNew(0, 0, 0), NewPiece(Beetle, Black, 1)).
+		Add(hexgrid.MustNew(1, 0, -1), NewPiece(QueenBee, Black, 1)).
+		SetToMove(Black).
+		SetMoveCount(3).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	tests := map[string]struct {
+		game *Game
+		s    string
+		want Move
+		err  error
+	}{
+		"Pass": {
+			s:    "pass",
+			want: Move{Kind: Pass},
+		},
+		"Placement": {
+			s:    `wQ wS1\`,
+			want: Move{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(1, 0, -1)},
+		},
+		"Movement": {
+			s:    "wS1 bG1/",
+			want: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(-1, 0, 1)},
+		},
+		"Climb": {
+			s:    "wS1 bG1",
+			want: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(-1, 1, 0)},
+		},
+		"Wrong number": {
+			s:   "wG2 wS1-",
+			err: ErrIllegalMove,
+		},
+		"Wrong colour": {
+			s:   "bQ wS1-",
+			err: ErrIllegalMove,
+		},
+		"Reference not on board": {
+			s:   "wQ bQ-",
+			err: ErrIllegalMove,
+		},
+		"Two directions": {
+			s:   "wQ -wS1-",
+			err: ErrInvalidNotation,
+		},
+		"Two prefixes": {
+			s:   "wQ /-wS1",
+			err: ErrInvalidNotation,
+		},
+		"Two prefixes reversed": {
+			s:   "wQ -/wS1",
+			err: ErrInvalidNotation,
+		},
+		"Two prefixes backslash": {
+			s:   `wQ \/wS1`,
+			err: ErrInvalidNotation,
+		},
+		"Two suffixes": {
+			s:   `wQ wS1-\`,
+			err: ErrInvalidNotation,
+		},
+		"Missing position": {
+			s:   "wQ",
+			err: ErrInvalidNotation,
+		},
+		"Too many fields": {
+			s:   "wQ wS1- bG1",
+			err: ErrInvalidNotation,
+		},
+		"Invalid piece": {
+			s:   "wS3 wS1-",
+			err: ErrInvalidNotation,
+		},
+		"Covered piece": {
+			game: covered,
+			s:    "wQ bQ-",
+			err:  ErrIllegalMove,
+		},
+		"Piece on top of stack": {
+			game: covered,
+			s:    "bB1 bQ-",
+			want: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(2, -1, -1)},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			game := test.game
+			if game == nil {
+				game = g
+			}
+			got, err := game.ParseMove(test.s)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Got error %v, want %v", err, test.err)
+			}
+			if err == nil && got != test.want {
+				t.Errorf("Got %+v, want %+v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestGameType(t *testing.T) {
+	tests := map[string]struct {
+		s    string
+		opts Options
+		err  error
+	}{
+		"Base": {
+			s: "Base",
+		},
+		"Expansions": {
+			s:    "Base+MLP",
+			opts: Options{Expansions: []int{Mosquito, Ladybug, Pillbug}},
+		},
+		"Single expansion": {
+			s:    "Base+L",
+			opts: Options{Expansions: []int{Ladybug}},
+		},
+		"Unknown expansion": {
+			s:   "Base+Q",
+			err: ErrInvalidNotation,
+		},
+		"Missing plus": {
+			s:   "BaseM",
+			err: ErrInvalidNotation,
+		},
+		"Not base": {
+			s:   "Extended",
+			err: ErrInvalidNotation,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			opts, err := ParseGameType(test.s)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Got error %v, want %v", err, test.err)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(opts, test.opts) {
+				t.Errorf("Got %+v, want %+v", opts, test.opts)
+			}
+			if got := FormatGameType(opts); got != test.s {
+				t.Errorf("Formatted as %q, want %q", got, test.s)
+			}
+		})
+	}
+}
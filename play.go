@@ -0,0 +1,338 @@
+package hive
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// Move kinds
+const (
+	Placement = iota
+	Movement
+	Pass
+)
+
+// Game results
+const (
+	InProgress = iota
+	BlackWins
+	WhiteWins
+	Draw
+)
+
+// Move represents the action taken by a player on their turn
+type Move struct {
+	Kind     int         // Placement, Movement or Pass
+	Creature int         // Creature to place, only used for placements
+	From     hexgrid.Hex // Hex of the piece to move, only used for movements
+	To       hexgrid.Hex // Destination of the piece
+	Ability  bool        // Movement made using the special ability of another piece
+	Via      hexgrid.Hex // Hex of the piece whose special ability is used, only used with Ability
+}
+
+// String describes a move by its hexes, such as "move (0, 0, 0) to (1, -1, 0)"
+func (m Move) String() string {
+	switch m.Kind {
+	case Pass:
+		return "pass"
+	case Placement:
+		name := fmt.Sprintf("creature %d", m.Creature)
+		if ct, ok := GetCreatureType(m.Creature); ok {
+			name = ct.Name
+		}
+		return fmt.Sprintf("place %s at %s", name, formatHex(m.To))
+	case Movement:
+		s := fmt.Sprintf("move %s to %s", formatHex(m.From), formatHex(m.To))
+		if m.Ability {
+			s += " using the piece at " + formatHex(m.Via)
+		}
+		return s
+	}
+	return fmt.Sprintf("unknown move kind %d", m.Kind)
+}
+
+// formatHex returns the cube coordinates of a hex for use in messages
+func formatHex(h hexgrid.Hex) string {
+	return fmt.Sprintf("(%d, %d, %d)", h.Q(), h.R(), h.S())
+}
+
+// matches returns true if other is a way of making move m.
+// A movement that does not name a special ability matches the same movement made with or without one,
+// while a movement made using an ability only matches the same ability used from the same hex.
+func (m Move) matches(other Move) bool {
+	if m.Kind != other.Kind {
+		return false
+	}
+	switch m.Kind {
+	case Placement:
+		return m.Creature == other.Creature && m.To == other.To
+	case Movement:
+		if m.Ability && (!other.Ability || m.Via != other.Via) {
+			return false
+		}
+		return m.From == other.From && m.To == other.To
+	}
+	return true
+}
+
+// Errors returned when generating or playing moves
+var (
+	ErrIllegalMove     = errors.New("move is not allowed")
+	ErrNotYourTurn     = errors.New("piece does not belong to the player to move")
+	ErrNotInReserve    = errors.New("no pieces of that creature left to place")
+	ErrGameOver        = errors.New("game has already finished")
+	ErrNoPiece         = errors.New("no piece at hex")
+	ErrUnknownCreature = errors.New("creature is not registered")
+)
+
+// MoveError is returned when a move cannot be played
+type MoveError struct {
+	Move Move
+	Err  error
+}
+
+func (e *MoveError) Error() string {
+	return fmt.Sprintf("cannot %v: %v", e.Move, e.Err)
+}
+
+// Unwrap returns the reason the move was rejected
+func (e *MoveError) Unwrap() error {
+	return e.Err
+}
+
+// queenDeadline is the turn by which a player must have placed their Queen Bee
+const queenDeadline = 4
+
+// NewGame returns a game with an empty board where White moves first.
+// Every creature listed in the expansions must be a registered expansion creature.
+func NewGame(opts Options) (*Game, error) {
+	for _, creature := range opts.Expansions {
+		if ct, ok := GetCreatureType(creature); !ok || !ct.Expansion {
+			return nil, fmt.Errorf("%w: %d is not an expansion creature", ErrUnknownCreature, creature)
+		}
+	}
+
+	g := &Game{
+		positions: map[hexgrid.Hex][]Piece{},
+		toMove:    White,
+		options:   opts,
+	}
+	for colour := 0; colour < MaxPlayers; colour++ {
+		g.reserves[colour] = getStartingSet(opts)
+	}
+	return g, nil
+}
+
+// ToMove returns the colour of the player whose turn it is
+func (g *Game) ToMove() int {
+	return g.toMove
+}
+
+// Reserve returns the number of each creature a player has yet to place
+func (g *Game) Reserve(colour int) map[int]int {
+	reserve := map[int]int{}
+	for creature, count := range g.reserves[colour] {
+		if count > 0 {
+			reserve[creature] = count
+		}
+	}
+	return reserve
+}
+
+// turnsTaken returns the number of turns a player has completed
+func (g *Game) turnsTaken(colour int) int {
+	if colour == g.toMove {
+		return g.moveCount / 2
+	}
+	return (g.moveCount + 1) / 2
+}
+
+// placeableCreatures returns the creatures a player may place this turn in ascending order
+func (g *Game) placeableCreatures(colour int) []int {
+	turn := g.turnsTaken(colour) + 1
+	if !g.queenPlaced(colour) && turn >= queenDeadline {
+		if g.reserves[colour][QueenBee] > 0 {
+			return []int{QueenBee}
+		}
+		return []int{}
+	}
+
+	creatures := []int{}
+	for _, creature := range g.reserveCreatures(colour) {
+		if creature == QueenBee && turn == 1 && g.options.NoQueenFirstTurn {
+			continue
+		}
+		creatures = append(creatures, creature)
+	}
+	return creatures
+}
+
+// reserveCreatures returns the creatures a player still has available to place in ascending order
+func (g *Game) reserveCreatures(colour int) []int {
+	creatures := []int{}
+	for creature, count := range g.reserves[colour] {
+		if count > 0 {
+			creatures = append(creatures, creature)
+		}
+	}
+	sort.Ints(creatures)
+	return creatures
+}
+
+// GetLegalMoves returns all moves available to the player whose turn it is.
+// The Queen Bee must be placed by a player's fourth turn and no pieces may move before it is placed.
+// A player with no placements or movements available must pass.
+func (g *Game) GetLegalMoves() ([]Move, error) {
+	moves, err := g.getPlacementsAndMovements(g.toMove)
+	if err != nil {
+		return nil, err
+	}
+	if len(moves) == 0 {
+		moves = append(moves, Move{Kind: Pass})
+	}
+	return moves, nil
+}
+
+// getPlacementsAndMovements returns the placements and movements a player could make on their next turn
+func (g *Game) getPlacementsAndMovements(colour int) ([]Move, error) {
+	moves := []Move{}
+
+	creatures := g.placeableCreatures(colour)
+	if len(creatures) > 0 {
+		for _, h := range GetPlacements(*g, colour) {
+			for _, creature := range creatures {
+				moves = append(moves, Move{Kind: Placement, Creature: creature, To: h})
+			}
+		}
+	}
+
+	available, err := GetAllAvailableMoves(*g, colour)
+	if err != nil {
+		return nil, err
+	}
+	for from, destinations := range available {
+		for _, to := range destinations {
+			moves = append(moves, Move{Kind: Movement, From: from, To: to})
+		}
+	}
+
+	moves = append(moves, g.getThrows(colour)...)
+
+	return moves, nil
+}
+
+// Result returns whether the game is in progress, won by either player or drawn.
+// A player loses when their Queen Bee is surrounded and the game is drawn if both are surrounded at once.
+// The game is also drawn when neither player can do anything but pass.
+func (g *Game) Result() (int, error) {
+	if result := g.queenResult(); result != InProgress {
+		return result, nil
+	}
+
+	for colour := 0; colour < MaxPlayers; colour++ {
+		moves, err := g.getPlacementsAndMovements(colour)
+		if err != nil {
+			return InProgress, err
+		}
+		if len(moves) > 0 {
+			return InProgress, nil
+		}
+	}
+	return Draw, nil
+}
+
+// queenResult returns the result decided by surrounded Queen Bees, or InProgress if neither is surrounded.
+// Unlike Result it does not need to generate any moves.
+func (g *Game) queenResult() int {
+	surrounded := [MaxPlayers]bool{}
+	for h, stack := range g.positions {
+		for _, piece := range stack {
+			if piece.creature == QueenBee && g.isSurrounded(h) {
+				surrounded[piece.colour] = true
+			}
+		}
+	}
+
+	switch {
+	case surrounded[Black] && surrounded[White]:
+		return Draw
+	case surrounded[Black]:
+		return WhiteWins
+	case surrounded[White]:
+		return BlackWins
+	}
+	return InProgress
+}
+
+// isSurrounded returns true if every hex adjacent to a hex is occupied
+func (g *Game) isSurrounded(h hexgrid.Hex) bool {
+	for _, neighbour := range h.GetAdjacent() {
+		if !g.checkSpaceOccupied(neighbour) {
+			return false
+		}
+	}
+	return true
+}
+
+// Play validates a move for the player whose turn it is and applies it to the game
+func (g *Game) Play(m Move) error {
+	if m.Kind == Placement {
+		if _, ok := GetCreatureType(m.Creature); !ok {
+			return &MoveError{Move: m, Err: ErrUnknownCreature}
+		}
+	}
+
+	result, err := g.Result()
+	if err != nil {
+		return err
+	}
+	if result != InProgress {
+		return &MoveError{Move: m, Err: ErrGameOver}
+	}
+
+	legalMoves, err := g.GetLegalMoves()
+	if err != nil {
+		return err
+	}
+	var legalMove Move
+	legal := false
+	for _, lm := range legalMoves {
+		if !m.matches(lm) {
+			continue
+		}
+		// Prefer a plain movement unless a special ability was asked for
+		legalMove = lm
+		legal = true
+		if lm.Ability == m.Ability {
+			break
+		}
+	}
+	if !legal {
+		piece, ok := g.topPiece(m.From)
+		if m.Kind == Movement && !ok {
+			return &MoveError{Move: m, Err: ErrNoPiece}
+		}
+		// Special abilities can move the opponent's pieces
+		if m.Kind == Movement && !m.Ability && piece.colour != g.toMove {
+			return &MoveError{Move: m, Err: ErrNotYourTurn}
+		}
+		if m.Kind == Placement && g.reserves[g.toMove][m.Creature] <= 0 {
+			return &MoveError{Move: m, Err: ErrNotInReserve}
+		}
+		return &MoveError{Move: m, Err: ErrIllegalMove}
+	}
+
+	entry := HistoryEntry{Move: legalMove}
+	switch m.Kind {
+	case Placement:
+		entry.Piece = Piece{creature: m.Creature, colour: g.toMove, number: g.nextNumber(m.Creature, g.toMove)}
+	case Movement:
+		entry.Piece, _ = g.topPiece(m.From)
+	}
+	g.apply(entry)
+	g.undone = nil
+	return nil
+}
@@ -0,0 +1,515 @@
+package hive
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// newTestGame returns a copy of a sample game with the given player to move.
+// Reserves hold the standard set less the pieces already on the board.
+func newTestGame(name string, toMove int) *Game {
+	tc := sampleGames[name]
+	g := tc.game.Copy()
+	g.toMove = toMove
+	for colour := 0; colour < MaxPlayers; colour++ {
+		g.reserves[colour] = getStartingSet(Options{})
+	}
+	for _, stack := range g.positions {
+		for _, piece := range stack {
+			g.reserves[piece.colour][piece.creature]--
+		}
+	}
+	return &g
+}
+
+// mustNewGame returns a new game and fails the test on error
+func mustNewGame(t testing.TB, opts Options) *Game {
+	t.Helper()
+	g, err := NewGame(opts)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return g
+}
+
+// mustGetLegalMoves returns the legal moves in a game and fails the test on error
+func mustGetLegalMoves(t *testing.T, g *Game) []Move {
+	t.Helper()
+	moves, err := g.GetLegalMoves()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return moves
+}
+
+// mustResult returns the result of a game and fails the test on error
+func mustResult(t *testing.T, g *Game) int {
+	t.Helper()
+	result, err := g.Result()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return result
+}
+
+func TestPlay(t *testing.T) {
+	tests := map[string]struct {
+		game *Game
+		move Move
+		want error
+	}{
+		"Movement": {
+			game: newTestGame("Game 3", White),
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(1, 0, -1)},
+		},
+		"Beetle climbs": {
+			game: newTestGame("Game 3", White),
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(0, 1, -1)},
+		},
+		"Placement": {
+			game: newTestGame("Game 3", Black),
+			move: Move{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(3, 0, -3)},
+		},
+		"Placement touching opponent": {
+			game: newTestGame("Game 3", Black),
+			move: Move{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(1, 0, -1)},
+			want: ErrIllegalMove,
+		},
+		"Movement not available": {
+			game: newTestGame("Game 3", White),
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(3, 0, -3)},
+			want: ErrIllegalMove,
+		},
+		"Movement from empty hex": {
+			game: newTestGame("Game 3", White),
+			move: Move{Kind: Movement, From: hexgrid.MustNew(5, 0, -5), To: hexgrid.MustNew(1, 0, -1)},
+			want: ErrNoPiece,
+		},
+		"Unknown creature": {
+			game: newTestGame("Game 3", Black),
+			move: Move{Kind: Placement, Creature: frog + 1, To: hexgrid.MustNew(3, 0, -3)},
+			want: ErrUnknownCreature,
+		},
+		"Creature not in reserve": {
+			game: newTestGame("Game 3", Black),
+			move: Move{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(3, 0, -3)},
+			want: ErrNotInReserve,
+		},
+		"Opponent piece": {
+			game: newTestGame("Game 3", Black),
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(1, 0, -1)},
+			want: ErrNotYourTurn,
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			toMove := tc.game.ToMove()
+			err := tc.game.Play(tc.move)
+			if !errors.Is(err, tc.want) {
+				t.Fatalf("Got error %v, want %v", err, tc.want)
+			}
+			if err != nil {
+				var moveErr *MoveError
+				if !errors.As(err, &moveErr) {
+					t.Errorf("Got error of type %T, want *MoveError", err)
+				}
+				if got := tc.game.ToMove(); got != toMove {
+					t.Errorf("Got %v to move after illegal move, want %v", got, toMove)
+				}
+				return
+			}
+			if got := tc.game.ToMove(); got == toMove {
+				t.Errorf("Got %v to move, want the other player", got)
+			}
+			piece, ok := tc.game.topPiece(tc.move.To)
+			if !ok || piece.colour != toMove {
+				t.Errorf("Got piece %v at destination, want a piece of colour %v", piece, toMove)
+			}
+		})
+	}
+}
+
+func TestPlayAlternatesTurns(t *testing.T) {
+	g := newTestGame("Game 3", White)
+	moves := []Move{
+		{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(1, 0, -1)},
+		{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(3, 0, -3)},
+		{Kind: Placement, Creature: Grasshopper, To: hexgrid.MustNew(-1, 0, 1)},
+	}
+	for i, m := range moves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	if got := g.ToMove(); got != Black {
+		t.Errorf("Got %v to move, want %v", got, Black)
+	}
+	if err := g.Play(moves[2]); !errors.Is(err, ErrIllegalMove) {
+		t.Errorf("Got error %v replaying an occupied placement, want %v", err, ErrIllegalMove)
+	}
+}
+
+func TestReserves(t *testing.T) {
+	g := mustNewGame(t, Options{})
+	standardSet := map[int]int{QueenBee: 1, Beetle: 2, Spider: 2, Grasshopper: 3, SoldierAnt: 3}
+	for colour := 0; colour < MaxPlayers; colour++ {
+		if got := g.Reserve(colour); !reflect.DeepEqual(got, standardSet) {
+			t.Errorf("Got reserve %v for %v, want %v", got, colourNames[colour], standardSet)
+		}
+	}
+
+	g = mustNewGame(t, Options{Expansions: []int{Mosquito}})
+	if got := g.Reserve(White)[Mosquito]; got != 1 {
+		t.Errorf("Got %v Mosquitoes in reserve, want 1", got)
+	}
+
+	g = newTestGame("Game 3", Black)
+	if err := g.Play(Move{Kind: Placement, Creature: Grasshopper, To: hexgrid.MustNew(3, 0, -3)}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := map[int]int{Beetle: 2, Spider: 2, Grasshopper: 1, SoldierAnt: 3}
+	if got := g.Reserve(Black); !reflect.DeepEqual(got, want) {
+		t.Errorf("Got reserve %v, want %v", got, want)
+	}
+
+	// Only creatures left in reserve may be placed
+	for _, m := range mustGetLegalMoves(t, g) {
+		if m.Kind == Placement && m.Creature == QueenBee {
+			t.Errorf("Got legal placement of a creature not in reserve: %+v", m)
+		}
+	}
+}
+
+func TestQueenRules(t *testing.T) {
+	// Returns the set of creatures that may be placed and whether any piece may move
+	summarise := func(g *Game) (map[int]struct{}, bool) {
+		creatures := map[int]struct{}{}
+		moved := false
+		for _, m := range mustGetLegalMoves(t, g) {
+			switch m.Kind {
+			case Placement:
+				creatures[m.Creature] = struct{}{}
+			case Movement:
+				moved = true
+			}
+		}
+		return creatures, moved
+	}
+
+	tests := map[string]struct {
+		moveCount     int
+		options       Options
+		wantCreatures map[int]struct{}
+	}{
+		"Second turn": {
+			moveCount:     2,
+			wantCreatures: map[int]struct{}{QueenBee: {}, Beetle: {}, Spider: {}, Grasshopper: {}, SoldierAnt: {}},
+		},
+		"Fourth turn": {
+			moveCount:     6,
+			wantCreatures: map[int]struct{}{QueenBee: {}},
+		},
+		"Tournament first turn": {
+			moveCount:     0,
+			options:       Options{NoQueenFirstTurn: true},
+			wantCreatures: map[int]struct{}{Beetle: {}, Spider: {}, Grasshopper: {}, SoldierAnt: {}},
+		},
+		"Tournament second turn": {
+			moveCount:     2,
+			options:       Options{NoQueenFirstTurn: true},
+			wantCreatures: map[int]struct{}{QueenBee: {}, Beetle: {}, Spider: {}, Grasshopper: {}, SoldierAnt: {}},
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			// White has not placed their Queen Bee in this game
+			g := newTestGame("Game 8", White)
+			g.moveCount = tc.moveCount
+			g.options = tc.options
+			gotCreatures, moved := summarise(g)
+			if !reflect.DeepEqual(gotCreatures, tc.wantCreatures) {
+				t.Errorf("Got placeable creatures %v, want %v", gotCreatures, tc.wantCreatures)
+			}
+			if moved {
+				t.Errorf("Got movements before the Queen Bee was placed")
+			}
+		})
+	}
+}
+
+func TestPlayOpening(t *testing.T) {
+	g := mustNewGame(t, Options{})
+	moves := []Move{
+		{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, 0, 0)},
+		{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, -1, 1)},
+		{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(0, 1, -1)},
+		{Kind: Placement, Creature: QueenBee, To: hexgrid.MustNew(0, -2, 2)},
+	}
+	for i, m := range moves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	if got := g.ToMove(); got != White {
+		t.Errorf("Got %v to move, want %v", got, White)
+	}
+}
+
+func TestResult(t *testing.T) {
+	blackQueen := hexgrid.MustNew(0, 0, 0)
+	whiteQueen := hexgrid.MustNew(3, 0, -3)
+
+	// Returns a game with each queen surrounded by beetles on all but the given number of sides
+	newGame := func(blackGaps, whiteGaps int) *Game {
+		g := &Game{positions: map[hexgrid.Hex][]Piece{}}
+		g.dropPiece(blackQueen, Piece{creature: QueenBee, colour: Black})
+		g.dropPiece(whiteQueen, Piece{creature: QueenBee, colour: White})
+		for i, h := range blackQueen.GetAdjacent() {
+			if i >= blackGaps {
+				g.dropPiece(h, Piece{creature: Beetle, colour: White})
+			}
+		}
+		for i, h := range whiteQueen.GetAdjacent() {
+			if i >= whiteGaps && !g.checkSpaceOccupied(h) {
+				g.dropPiece(h, Piece{creature: Beetle, colour: Black})
+			}
+		}
+		return g
+	}
+
+	tests := map[string]struct {
+		game *Game
+		want int
+	}{
+		"In progress": {game: newGame(1, 1), want: InProgress},
+		"White wins":  {game: newGame(0, 1), want: WhiteWins},
+		"Black wins":  {game: newGame(1, 0), want: BlackWins},
+		"Draw":        {game: newGame(0, 0), want: Draw},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := mustResult(t, tc.game); got != tc.want {
+				t.Errorf("Got %v, want %v", got, tc.want)
+			}
+			if tc.want == InProgress {
+				return
+			}
+			err := tc.game.Play(Move{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(-2, 0, 2)})
+			if !errors.Is(err, ErrGameOver) {
+				t.Errorf("Got error %v playing after the game ended, want %v", err, ErrGameOver)
+			}
+		})
+	}
+}
+
+func TestPass(t *testing.T) {
+	// White's Queen Bee holds the hive together and White has nothing left to place
+	//  __
+	// / S\
+	// \__/
+	// /QB\
+	// \__/
+	// / S\
+	// \__/
+	newGame := func(blackReserve map[int]int) *Game {
+		g := &Game{
+			positions: map[hexgrid.Hex][]Piece{
+				hexgrid.MustNew(0, -1, 1): {Piece{creature: Spider, colour: Black}},
+				hexgrid.MustNew(0, 0, 0):  {Piece{creature: QueenBee, colour: White}},
+				hexgrid.MustNew(0, 1, -1): {Piece{creature: Spider, colour: Black}},
+			},
+			toMove:    White,
+			moveCount: 2,
+		}
+		g.reserves[White] = map[int]int{}
+		g.reserves[Black] = blackReserve
+		return g
+	}
+
+	t.Run("Forced pass", func(t *testing.T) {
+		g := newGame(map[int]int{QueenBee: 1})
+		want := []Move{{Kind: Pass}}
+		if got := mustGetLegalMoves(t, g); !reflect.DeepEqual(got, want) {
+			t.Fatalf("Got %v, want %v", got, want)
+		}
+		if got := mustResult(t, g); got != InProgress {
+			t.Errorf("Got result %v, want %v", got, InProgress)
+		}
+		if err := g.Play(Move{Kind: Pass}); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if got := g.ToMove(); got != Black {
+			t.Errorf("Got %v to move, want %v", got, Black)
+		}
+		if err := g.Play(Move{Kind: Pass}); !errors.Is(err, ErrIllegalMove) {
+			t.Errorf("Got error %v passing with moves available, want %v", err, ErrIllegalMove)
+		}
+	})
+
+	t.Run("Both players stuck", func(t *testing.T) {
+		g := newGame(map[int]int{})
+		if got := mustResult(t, g); got != Draw {
+			t.Errorf("Got result %v, want %v", got, Draw)
+		}
+	})
+}
+
+func TestPillbug(t *testing.T) {
+	up := hexgrid.MustNew(0, -1, 1)
+	upRight := hexgrid.MustNew(1, -1, 0)
+	upLeft := hexgrid.MustNew(-1, 0, 1)
+
+	// Returns a game with White to move and the given White creatures at the origin and down right
+	//     __
+	//    / A\
+	//    \__/
+	//  __/ *\__
+	// /QB\__/  \
+	// \__/QB\__/
+	//    \__/
+	newGame := func(centre, downRight int) *Game {
+		positions := map[hexgrid.Hex][]Piece{
+			hexgrid.MustNew(0, 0, 0):  {Piece{creature: centre, colour: White}},
+			up:                        {Piece{creature: SoldierAnt, colour: Black}},
+			hexgrid.MustNew(0, 1, -1): {Piece{creature: QueenBee, colour: White}},
+			hexgrid.MustNew(-1, 1, 0): {Piece{creature: QueenBee, colour: Black}},
+			hexgrid.MustNew(1, 0, -1): {Piece{creature: downRight, colour: White}},
+		}
+		return &Game{positions: positions, toMove: White, moveCount: 8}
+	}
+
+	// Returns true if a move is in a slice of moves
+	contains := func(moves []Move, m Move) bool {
+		for _, mm := range moves {
+			if mm == m {
+				return true
+			}
+		}
+		return false
+	}
+
+	t.Run("Pillbug throws opponent", func(t *testing.T) {
+		g := newGame(Pillbug, Spider)
+		throw := Move{Kind: Movement, From: up, To: upRight, Ability: true, Via: hexgrid.MustNew(0, 0, 0)}
+		if !contains(mustGetLegalMoves(t, g), throw) {
+			t.Fatalf("Got moves %v, want to include %v", mustGetLegalMoves(t, g), throw)
+		}
+		if err := g.Play(Move{Kind: Movement, From: up, To: upRight}); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if piece, _ := g.topPiece(upRight); piece.creature != SoldierAnt {
+			t.Errorf("Got %v at destination, want the thrown Soldier Ant", piece)
+		}
+
+		// The thrown piece cannot move on the following turn
+		for _, m := range mustGetLegalMoves(t, g) {
+			if m.Kind == Movement && m.From == upRight {
+				t.Errorf("Got move %v for the piece thrown last turn", m)
+			}
+		}
+	})
+
+	t.Run("Throw must use a Pillbug", func(t *testing.T) {
+		g := newGame(Pillbug, Spider)
+		for _, via := range []hexgrid.Hex{hexgrid.MustNew(1, 0, -1), upLeft} {
+			m := Move{Kind: Movement, From: up, To: upRight, Ability: true, Via: via}
+			if err := g.Play(m); !errors.Is(err, ErrIllegalMove) {
+				t.Errorf("Got error %v for throw via %v, want %v", err, via, ErrIllegalMove)
+			}
+		}
+
+		throw := Move{Kind: Movement, From: up, To: upRight, Ability: true, Via: hexgrid.MustNew(0, 0, 0)}
+		if err := g.Play(throw); err != nil {
+			t.Fatalf("Unexpected error %v", err)
+		}
+		if history := g.History(); len(history) != 1 || history[0].Move != throw {
+			t.Errorf("Got history %v, want %v", history, throw)
+		}
+	})
+
+	t.Run("Mosquito touching Pillbug", func(t *testing.T) {
+		g := newGame(Mosquito, Pillbug)
+		throw := Move{Kind: Movement, From: up, To: upLeft, Ability: true, Via: hexgrid.MustNew(0, 0, 0)}
+		if !contains(mustGetLegalMoves(t, g), throw) {
+			t.Errorf("Got moves %v, want to include %v", mustGetLegalMoves(t, g), throw)
+		}
+	})
+
+	t.Run("Last moved piece cannot be thrown", func(t *testing.T) {
+		g := newGame(Pillbug, Spider)
+		g.lastMoved, g.hasMoved = up, true
+		for _, m := range mustGetLegalMoves(t, g) {
+			if m.Ability && m.From == up {
+				t.Errorf("Got move %v for the piece played last turn", m)
+			}
+		}
+	})
+}
+
+func TestNewGame(t *testing.T) {
+	tests := map[string]struct {
+		options Options
+		want    error
+	}{
+		"Base game":             {options: Options{}},
+		"Expansions":            {options: Options{Expansions: []int{Mosquito, Ladybug, Pillbug}}},
+		"Unregistered creature": {options: Options{Expansions: []int{frog + 1}}, want: ErrUnknownCreature},
+		"Base game creature":    {options: Options{Expansions: []int{Spider}}, want: ErrUnknownCreature},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewGame(tc.options); !errors.Is(err, tc.want) {
+				t.Errorf("Got error %v, want %v", err, tc.want)
+			}
+		})
+	}
+}
+
+func TestMoveString(t *testing.T) {
+	tests := map[string]struct {
+		move Move
+		want string
+	}{
+		"Pass":      {move: Move{Kind: Pass}, want: "pass"},
+		"Placement": {move: Move{Kind: Placement, Creature: SoldierAnt, To: hexgrid.MustNew(1, -1, 0)}, want: "place Soldier Ant at (1, -1, 0)"},
+		"Unknown creature": {
+			move: Move{Kind: Placement, Creature: frog + 1, To: hexgrid.MustNew(0, 0, 0)},
+			want: "place creature 101 at (0, 0, 0)",
+		},
+		"Movement": {move: Move{Kind: Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(0, 1, -1)}, want: "move (0, 0, 0) to (0, 1, -1)"},
+		"Throw": {
+			move: Move{Kind: Movement, From: hexgrid.MustNew(0, -1, 1), To: hexgrid.MustNew(1, -1, 0), Ability: true, Via: hexgrid.MustNew(0, 0, 0)},
+			want: "move (0, -1, 1) to (1, -1, 0) using the piece at (0, 0, 0)",
+		},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.move.String(); got != tc.want {
+				t.Errorf("Got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPlayNumbersPieces(t *testing.T) {
+	g := mustNewGame(t, Options{})
+	moves := []Move{
+		{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, 0, 0)},
+		{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, -1, 1)},
+		{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, 1, -1)},
+	}
+	for i, m := range moves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	want := []Piece{NewPiece(Spider, White, 1), NewPiece(Spider, Black, 1), NewPiece(Spider, White, 2)}
+	for i, m := range moves {
+		if got, _ := g.Top(m.To); got != want[i] {
+			t.Errorf("Got %v at %v, want %v", got, m.To, want[i])
+		}
+	}
+}
@@ -0,0 +1,185 @@
+package hive
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// Separators and markers used in position strings
+const (
+	positionEmpty      = "-"
+	positionHexes      = ";"
+	positionStack      = "+"
+	positionColours    = "/"
+	positionTournament = ",NoQueenFirstTurn"
+)
+
+// reserveOrder lists the colours in the order their reserves are written, starting with the first to move
+var reserveOrder = []int{White, Black}
+
+// FormatPosition returns a one line description of a position, such as
+//
+//	Base+M -1,0=wB1;0,0=wQ;1,-1=bQ b 2 wB1S2G3A3M1/bB2S2G3A3M1 -1,0
+//
+// The fields are separated by spaces and are:
+//   - the game type, followed by ",NoQueenFirstTurn" when the tournament rule is used
+//   - the stacks of each occupied hex as q,r=pieces, listed bottom to top and separated by semicolons, or - for an empty board
+//   - the colour to move, w or b
+//   - the turn number
+//   - each player's reserve as counts of creature letters
+//   - the hex of the last moved piece as q,r, or - if there is none
+func FormatPosition(g *Game) string {
+	gameType := FormatGameType(g.options)
+	if g.options.NoQueenFirstTurn {
+		gameType += positionTournament
+	}
+
+	var stacks []string
+	for _, h := range g.Occupied() {
+		var names []string
+		for _, p := range g.positions[h] {
+			names = append(names, FormatPiece(p))
+		}
+		stacks = append(stacks, formatPositionHex(h)+"="+strings.Join(names, positionStack))
+	}
+	board := positionEmpty
+	if len(stacks) > 0 {
+		board = strings.Join(stacks, positionHexes)
+	}
+
+	lastMoved := positionEmpty
+	if h, ok := g.LastMoved(); ok {
+		lastMoved = formatPositionHex(h)
+	}
+
+	return strings.Join([]string{
+		gameType,
+		board,
+		string(colourLetters[g.toMove]),
+		strconv.Itoa(g.Turn()),
+		formatReserves(g),
+		lastMoved,
+	}, " ")
+}
+
+// ParsePosition returns the game described by a position string written by FormatPosition.
+// The reserves given must match the pieces left over from the board.
+func ParsePosition(s string) (*Game, error) {
+	fields := strings.Fields(s)
+	if len(fields) != 6 {
+		return nil, fmt.Errorf("%w: position needs 6 fields, got %d", ErrInvalidNotation, len(fields))
+	}
+
+	gameType := fields[0]
+	tournament := strings.HasSuffix(gameType, positionTournament)
+	opts, err := ParseGameType(strings.TrimSuffix(gameType, positionTournament))
+	if err != nil {
+		return nil, err
+	}
+	opts.NoQueenFirstTurn = tournament
+	b := NewBuilder(opts)
+
+	if fields[1] != positionEmpty {
+		for _, stack := range strings.Split(fields[1], positionHexes) {
+			parts := strings.Split(stack, "=")
+			if len(parts) != 2 {
+				return nil, fmt.Errorf("%w: stack %q", ErrInvalidNotation, stack)
+			}
+			h, err := parsePositionHex(parts[0])
+			if err != nil {
+				return nil, err
+			}
+			for _, name := range strings.Split(parts[1], positionStack) {
+				p, err := ParsePiece(name)
+				if err != nil {
+					return nil, err
+				}
+				b.Add(h, p)
+			}
+		}
+	}
+
+	toMove := -1
+	for colour, letter := range colourLetters {
+		if fields[2] == string(letter) {
+			toMove = colour
+		}
+	}
+	if toMove < 0 {
+		return nil, fmt.Errorf("%w: colour to move %q", ErrInvalidNotation, fields[2])
+	}
+	turn, err := strconv.Atoi(fields[3])
+	if err != nil || turn < 1 {
+		return nil, fmt.Errorf("%w: turn %q", ErrInvalidNotation, fields[3])
+	}
+	// White moves first so Black has one more move played before it on the same turn
+	moveCount := 2 * (turn - 1)
+	if toMove == Black {
+		moveCount++
+	}
+	b.SetToMove(toMove).SetMoveCount(moveCount)
+
+	if fields[5] != positionEmpty {
+		h, err := parsePositionHex(fields[5])
+		if err != nil {
+			return nil, err
+		}
+		b.SetLastMoved(h)
+	}
+
+	g, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	if want := formatReserves(g); fields[4] != want {
+		return nil, fmt.Errorf("%w: reserves %q do not match the board, which leaves %q", ErrInvalidPosition, fields[4], want)
+	}
+	return g, nil
+}
+
+// formatReserves returns the reserves of both players separated by a slash
+func formatReserves(g *Game) string {
+	var reserves []string
+	for _, colour := range reserveOrder {
+		reserves = append(reserves, formatReserve(colour, g.reserves[colour]))
+	}
+	return strings.Join(reserves, positionColours)
+}
+
+// formatReserve returns the colour letter followed by the letter and count of each creature in a reserve
+func formatReserve(colour int, reserve map[int]int) string {
+	s := string(colourLetters[colour])
+	for _, creature := range GetCreatures() {
+		if count := reserve[creature]; count > 0 {
+			ct, _ := GetCreatureType(creature)
+			s += string(ct.Letter) + strconv.Itoa(count)
+		}
+	}
+	return s
+}
+
+// formatPositionHex returns the q and r coordinates of a hex separated by a comma
+func formatPositionHex(h hexgrid.Hex) string {
+	return fmt.Sprintf("%d,%d", h.Q(), h.R())
+}
+
+// parsePositionHex returns the hex for q and r coordinates separated by a comma
+func parsePositionHex(s string) (hexgrid.Hex, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 2 {
+		return hexgrid.Hex{}, fmt.Errorf("%w: hex %q", ErrInvalidNotation, s)
+	}
+	q, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return hexgrid.Hex{}, fmt.Errorf("%w: hex %q", ErrInvalidNotation, s)
+	}
+	r, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return hexgrid.Hex{}, fmt.Errorf("%w: hex %q", ErrInvalidNotation, s)
+	}
+	return hexgrid.New(q, r, -q-r)
+}
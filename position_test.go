@@ -0,0 +1,134 @@
+package hive
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+func TestFormatPosition(t *testing.T) {
+	tests := map[string]struct {
+		moves []Move
+		opts  Options
+		want  string
+	}{
+		"Empty": {
+			want: "Base - w 1 wQ1B2S2G3A3/bQ1B2S2G3A3 -",
+		},
+		"Opening": {
+			moves: historyTestMoves[:3],
+			opts:  Options{Expansions: []int{Mosquito}},
+			want:  "Base+M 0,-1=bQ;0,0=wB1;1,0=wQ b 2 wB1S2G3A3M1/bB2S2G3A3M1 1,0",
+		},
+		"Stack": {
+			moves: historyTestMoves,
+			want:  "Base -1,-1=bS2;0,-2=bS1;0,-1=bQ+wB1;1,-1=wQ b 4 wB1S2G3A3/bB2G3A3 0,-1",
+		},
+		"Pass": {
+			moves: []Move{{Kind: Placement, Creature: Spider, To: hexgrid.MustNew(0, 0, 0)}, {Kind: Pass}},
+			opts:  Options{NoQueenFirstTurn: true},
+			want:  "Base,NoQueenFirstTurn 0,0=wS1 w 2 wQ1B2S1G3A3/bQ1B2S2G3A3 -",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := mustNewGame(t, test.opts)
+			for i, m := range test.moves {
+				// Pass is not legal here, so apply it directly
+				if m.Kind == Pass {
+					g.apply(HistoryEntry{Move: m})
+					continue
+				}
+				if err := g.Play(m); err != nil {
+					t.Fatalf("Move %d: unexpected error %v", i, err)
+				}
+			}
+
+			got := FormatPosition(g)
+			if got != test.want {
+				t.Fatalf("Got %q, want %q", got, test.want)
+			}
+
+			parsed, err := ParsePosition(got)
+			if err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			if !sameState(parsed, g) {
+				t.Errorf("Parsed position does not match the game")
+			}
+			if parsed.Options().NoQueenFirstTurn != test.opts.NoQueenFirstTurn {
+				t.Errorf("Tournament rule not restored")
+			}
+		})
+	}
+}
+
+func TestParsePositionErrors(t *testing.T) {
+	tests := map[string]struct {
+		s   string
+		err error
+	}{
+		"Missing field": {
+			s:   "Base - w 1 wQ1B2S2G3A3/bQ1B2S2G3A3",
+			err: ErrInvalidNotation,
+		},
+		"Unknown game type": {
+			s:   "Other - w 1 wQ1B2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidNotation,
+		},
+		"Invalid stack": {
+			s:   "Base 0,0 w 1 wQ1B2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidNotation,
+		},
+		"Invalid hex": {
+			s:   "Base 0=wQ b 1 wB2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidNotation,
+		},
+		"Invalid piece": {
+			s:   "Base 0,0=wQ+xB1 b 1 wB2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidNotation,
+		},
+		"Invalid colour": {
+			s:   "Base 0,0=wQ x 1 wB2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidNotation,
+		},
+		"Invalid turn": {
+			s:   "Base 0,0=wQ b 0 wB2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidNotation,
+		},
+		"Invalid last moved": {
+			s:   "Base 0,0=wQ b 1 wB2S2G3A3/bQ1B2S2G3A3 0",
+			err: ErrInvalidNotation,
+		},
+		"Last moved not occupied": {
+			s:   "Base 0,0=wQ b 1 wB2S2G3A3/bQ1B2S2G3A3 1,0",
+			err: ErrInvalidPosition,
+		},
+		"Disconnected": {
+			s:   "Base 0,0=wQ;3,0=bQ w 2 wB2S2G3A3/bB2S2G3A3 -",
+			err: ErrInvalidPosition,
+		},
+		"Duplicate piece": {
+			s:   "Base 0,0=wQ;1,0=wQ b 2 wB2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidPosition,
+		},
+		"Ground piece stacked": {
+			s:   "Base 0,0=wQ+wS1 b 1 wB2S1G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidPosition,
+		},
+		"Reserves do not match": {
+			s:   "Base 0,0=wQ b 1 wQ1B2S2G3A3/bQ1B2S2G3A3 -",
+			err: ErrInvalidPosition,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := ParsePosition(test.s); !errors.Is(err, test.err) {
+				t.Errorf("Got error %v, want %v", err, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,234 @@
+package hive
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Errors returned when reading or writing game records
+var (
+	ErrInvalidRecord     = errors.New("invalid game record")
+	ErrIncompleteHistory = errors.New("game history does not start from an empty board")
+)
+
+// Tags holding the rules and outcome of a recorded game.
+// They are written from the game itself and checked against it when a record is read.
+const (
+	TagGameType         = "GameType"
+	TagResult           = "Result"
+	TagNoQueenFirstTurn = "NoQueenFirstTurn"
+)
+
+// tagOrder lists the tags written before any others, in order
+var tagOrder = []string{"Event", "Site", "Date", "White", "Black", TagGameType, TagNoQueenFirstTurn, TagResult}
+
+// resultNames are the values of the Result tag
+var resultNames = map[int]string{
+	InProgress: "InProgress",
+	BlackWins:  "BlackWins",
+	WhiteWins:  "WhiteWins",
+	Draw:       "Draw",
+}
+
+// Record is a saved game along with metadata such as the players and date
+type Record struct {
+	Tags map[string]string
+	Game *Game
+}
+
+// RecordError reports the line of a game record that could not be read
+type RecordError struct {
+	Line int
+	Err  error
+}
+
+func (e *RecordError) Error() string {
+	return fmt.Sprintf("line %d: %v", e.Line, e.Err)
+}
+
+func (e *RecordError) Unwrap() error {
+	return e.Err
+}
+
+// WriteRecord writes a game record.
+// Tags are written first, one per line as [Name "value"], followed by one numbered line per move.
+// The game type, tournament rule and result tags are taken from the game rather than the record tags.
+func WriteRecord(w io.Writer, rec Record) error {
+	// Replay the game from the start so each move is written relative to the position it was played in
+	replay := rec.Game.Copy()
+	if err := replay.GoTo(replay.moveCount - len(replay.history)); err != nil {
+		return err
+	}
+	if replay.moveCount != 0 || len(replay.positions) != 0 {
+		return ErrIncompleteHistory
+	}
+
+	result, err := rec.Game.Result()
+	if err != nil {
+		return err
+	}
+	tags := map[string]string{}
+	for name, value := range rec.Tags {
+		tags[name] = value
+	}
+	tags[TagGameType] = FormatGameType(rec.Game.options)
+	tags[TagResult] = resultNames[result]
+	delete(tags, TagNoQueenFirstTurn)
+	if rec.Game.options.NoQueenFirstTurn {
+		tags[TagNoQueenFirstTurn] = strconv.FormatBool(true)
+	}
+
+	bw := bufio.NewWriter(w)
+	for _, name := range sortTags(tags) {
+		fmt.Fprintf(bw, "[%s %s]\n", name, strconv.Quote(tags[name]))
+	}
+	if len(rec.Game.history) > 0 {
+		fmt.Fprintln(bw)
+	}
+	for i := range rec.Game.history {
+		str, err := replay.FormatMove(replay.undone[len(replay.undone)-1].Move)
+		if err != nil {
+			return err
+		}
+		fmt.Fprintf(bw, "%d. %s\n", i+1, str)
+		if err := replay.Redo(); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// sortTags returns tag names with the standard tags first and the rest in alphabetical order
+func sortTags(tags map[string]string) []string {
+	var names, others []string
+	for _, name := range tagOrder {
+		if _, ok := tags[name]; ok {
+			names = append(names, name)
+		}
+	}
+	for name := range tags {
+		standard := false
+		for _, s := range tagOrder {
+			standard = standard || name == s
+		}
+		if !standard {
+			others = append(others, name)
+		}
+	}
+	sort.Strings(others)
+	return append(names, others...)
+}
+
+// ReadRecord reads a game record, replaying its moves to rebuild the game.
+// A game without a GameType tag is played with the base set.
+func ReadRecord(r io.Reader) (*Record, error) {
+	rec := &Record{Tags: map[string]string{}}
+	lineNumber := 0
+	fail := func(format string, a ...interface{}) (*Record, error) {
+		return nil, &RecordError{Line: lineNumber, Err: fmt.Errorf(format, a...)}
+	}
+
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		lineNumber++
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if rec.Game != nil {
+				return fail("%w: tag after moves", ErrInvalidRecord)
+			}
+			name, value, err := parseTag(line)
+			if err != nil {
+				return fail("%w", err)
+			}
+			if _, ok := rec.Tags[name]; ok {
+				return fail("%w: duplicate tag %s", ErrInvalidRecord, name)
+			}
+			rec.Tags[name] = value
+			continue
+		}
+
+		if rec.Game == nil {
+			g, err := newRecordGame(rec.Tags)
+			if err != nil {
+				return fail("%w", err)
+			}
+			rec.Game = g
+		}
+		fields := strings.SplitN(line, ". ", 2)
+		if len(fields) != 2 || fields[0] != strconv.Itoa(rec.Game.moveCount+1) {
+			return fail("%w: expected move %d, got %q", ErrInvalidRecord, rec.Game.moveCount+1, line)
+		}
+		m, err := rec.Game.ParseMove(fields[1])
+		if err != nil {
+			return fail("%w", err)
+		}
+		if err := rec.Game.Play(m); err != nil {
+			return fail("%w", err)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if rec.Game == nil {
+		g, err := newRecordGame(rec.Tags)
+		if err != nil {
+			return fail("%w", err)
+		}
+		rec.Game = g
+	}
+	if value, ok := rec.Tags[TagResult]; ok {
+		result, err := rec.Game.Result()
+		if err != nil {
+			return fail("%w", err)
+		}
+		if value != resultNames[result] {
+			return fail("%w: result %s does not match the moves, which give %s", ErrInvalidRecord, value, resultNames[result])
+		}
+	}
+	return rec, nil
+}
+
+// parseTag returns the name and value of a tag line such as [White "Alice"]
+func parseTag(line string) (string, string, error) {
+	if !strings.HasSuffix(line, "]") {
+		return "", "", fmt.Errorf("%w: unterminated tag %q", ErrInvalidRecord, line)
+	}
+	fields := strings.SplitN(line[1:len(line)-1], " ", 2)
+	if len(fields) != 2 || fields[0] == "" {
+		return "", "", fmt.Errorf("%w: tag without a value %q", ErrInvalidRecord, line)
+	}
+	name := fields[0]
+	value, err := strconv.Unquote(strings.TrimSpace(fields[1]))
+	if err != nil {
+		return "", "", fmt.Errorf("%w: tag value is not quoted %q", ErrInvalidRecord, line)
+	}
+	return name, value, nil
+}
+
+// newRecordGame returns a new game with the rules given by the tags of a record
+func newRecordGame(tags map[string]string) (*Game, error) {
+	gameType, ok := tags[TagGameType]
+	if !ok {
+		gameType = BaseGameType
+	}
+	opts, err := ParseGameType(gameType)
+	if err != nil {
+		return nil, err
+	}
+	if value, ok := tags[TagNoQueenFirstTurn]; ok {
+		if opts.NoQueenFirstTurn, err = strconv.ParseBool(value); err != nil {
+			return nil, fmt.Errorf("%w: %s tag %q is not a boolean", ErrInvalidRecord, TagNoQueenFirstTurn, value)
+		}
+	}
+	return NewGame(opts)
+}
@@ -0,0 +1,199 @@
+package hive
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+// historyTestRecord is the record of historyTestMoves played with the Pillbug expansion
+const historyTestRecord = `[Date "2024.05.01"]
+[White "Alice"]
+[Black "Bob \"B\""]
+[GameType "Base+P"]
+[Result "InProgress"]
+[Round "3"]
+
+1. wB1
+2. bQ wB1/
+3. wQ wB1\
+4. bS1 bQ/
+5. wQ wB1-
+6. bS2 -bS1
+7. wB1 bQ
+`
+
+func TestWriteRecord(t *testing.T) {
+	g := mustNewGame(t, Options{Expansions: []int{Pillbug}})
+	for i, m := range historyTestMoves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	// Undone moves are not part of the record
+	if err := g.Play(Move{Kind: Placement, Creature: Grasshopper, To: historyTestMoves[3].To.Move(0)}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := g.Undo(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	tags := map[string]string{
+		"White":     "Alice",
+		"Black":     `Bob "B"`,
+		"Date":      "2024.05.01",
+		"Round":     "3",
+		TagResult:   "ignored",
+		TagGameType: "ignored",
+	}
+	var buf bytes.Buffer
+	if err := WriteRecord(&buf, Record{Tags: tags, Game: g}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if got := buf.String(); got != historyTestRecord {
+		t.Errorf("Got\n%s\nwant\n%s", got, historyTestRecord)
+	}
+}
+
+func TestWriteRecordIncompleteHistory(t *testing.T) {
+	g, err := NewBuilder(Options{}).Add(historyTestMoves[0].To, NewPiece(QueenBee, White, 1)).SetToMove(Black).SetMoveCount(1).Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if err := WriteRecord(&bytes.Buffer{}, Record{Game: g}); !errors.Is(err, ErrIncompleteHistory) {
+		t.Errorf("Got error %v, want %v", err, ErrIncompleteHistory)
+	}
+}
+
+func TestRecordRoundTrip(t *testing.T) {
+	rec, err := ReadRecord(strings.NewReader(historyTestRecord))
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	want := mustNewGame(t, Options{Expansions: []int{Pillbug}})
+	for i, m := range historyTestMoves {
+		if err := want.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	if !sameState(rec.Game, want) {
+		t.Errorf("Replayed game does not match the moves played")
+	}
+	if !reflect.DeepEqual(rec.Game.Options(), want.Options()) {
+		t.Errorf("Got options %+v, want %+v", rec.Game.Options(), want.Options())
+	}
+	if rec.Tags["Black"] != `Bob "B"` {
+		t.Errorf("Got Black tag %q, want %q", rec.Tags["Black"], `Bob "B"`)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteRecord(&buf, *rec); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if got := buf.String(); got != historyTestRecord {
+		t.Errorf("Got\n%s\nwant\n%s", got, historyTestRecord)
+	}
+}
+
+func TestRecordTournamentRule(t *testing.T) {
+	g := mustNewGame(t, Options{NoQueenFirstTurn: true})
+	var buf bytes.Buffer
+	if err := WriteRecord(&buf, Record{Game: g}); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := "[GameType \"Base\"]\n[NoQueenFirstTurn \"true\"]\n[Result \"InProgress\"]\n"
+	if got := buf.String(); got != want {
+		t.Fatalf("Got\n%s\nwant\n%s", got, want)
+	}
+
+	rec, err := ReadRecord(&buf)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	if !rec.Game.Options().NoQueenFirstTurn {
+		t.Errorf("Tournament rule not restored")
+	}
+}
+
+func TestReadRecordErrors(t *testing.T) {
+	tests := map[string]struct {
+		record string
+		line   int
+		err    error
+	}{
+		"Unterminated tag": {
+			record: "[White \"Alice\"\n",
+			line:   1,
+			err:    ErrInvalidRecord,
+		},
+		"Unquoted tag": {
+			record: "[White \"Alice\"]\n[Black Bob]\n",
+			line:   2,
+			err:    ErrInvalidRecord,
+		},
+		"Duplicate tag": {
+			record: "[White \"Alice\"]\n[White \"Bob\"]\n",
+			line:   2,
+			err:    ErrInvalidRecord,
+		},
+		"Unknown game type": {
+			record: "[GameType \"Base+X\"]\n\n1. wS1\n",
+			line:   3,
+			err:    ErrInvalidNotation,
+		},
+		"Invalid tournament rule": {
+			record: "[NoQueenFirstTurn \"maybe\"]\n",
+			line:   1,
+			err:    ErrInvalidRecord,
+		},
+		"Tag after moves": {
+			record: "1. wS1\n[White \"Alice\"]\n",
+			line:   2,
+			err:    ErrInvalidRecord,
+		},
+		"Wrong move number": {
+			record: "1. wS1\n3. bS1 wS1-\n",
+			line:   2,
+			err:    ErrInvalidRecord,
+		},
+		"Invalid notation": {
+			record: "1. wS1\n2. bZ1 wS1-\n",
+			line:   2,
+			err:    ErrInvalidNotation,
+		},
+		"Illegal move": {
+			record: "1. wS1\n2. bS1 wS1-\n3. wQ bS1-\n",
+			line:   3,
+			err:    ErrIllegalMove,
+		},
+		"Expansion not in game": {
+			record: "1. wS1\n2. bP wS1-\n",
+			line:   2,
+			err:    ErrNotInReserve,
+		},
+		"Wrong result": {
+			record: "[Result \"WhiteWins\"]\n\n1. wS1\n",
+			line:   3,
+			err:    ErrInvalidRecord,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := ReadRecord(strings.NewReader(test.record))
+			if !errors.Is(err, test.err) {
+				t.Fatalf("Got error %v, want %v", err, test.err)
+			}
+			var recordErr *RecordError
+			if !errors.As(err, &recordErr) {
+				t.Fatalf("Got error %v, want a RecordError", err)
+			}
+			if recordErr.Line != test.line {
+				t.Errorf("Got line %d, want %d", recordErr.Line, test.line)
+			}
+		})
+	}
+}
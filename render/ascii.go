@@ -0,0 +1,113 @@
+package render
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// asciiColours are the letters showing which player a piece belongs to
+var asciiColours = map[int]byte{
+	hive.Black: 'b',
+	hive.White: 'w',
+}
+
+// asciiCanvas is a grid of characters that hexes are drawn on to
+type asciiCanvas map[[2]int]byte
+
+// set writes a string starting at a column and line
+func (c asciiCanvas) set(x, y int, s string) {
+	for i := 0; i < len(s); i++ {
+		c[[2]int{x + i, y}] = s[i]
+	}
+}
+
+// asciiPosition returns the column of the left edge and the line of the middle of a hex
+func asciiPosition(h hexgrid.Hex) (int, int) {
+	return 3 * h.Q(), 2*h.R() + h.Q()
+}
+
+// ASCII draws a game as hexagons in the style of the diagrams in the package tests, for example
+//
+//	    __
+//	 __/bB\
+//	/wQ\_2/
+//	\__/  \
+//	   \*_/
+//
+// Each piece shows its colour and creature letter, and a stack shows the piece on top with its height on its
+// lower edge. Highlighted hexes, such as the moves from hive.GetAvailableMoves, are starred on their lower edge.
+func ASCII(g *hive.Game, highlights ...hexgrid.Hex) string {
+	canvas := asciiCanvas{}
+	outline := func(h hexgrid.Hex, content string) {
+		x, y := asciiPosition(h)
+		canvas.set(x+1, y-1, "__")
+		canvas.set(x, y, "/"+content+`\`)
+		canvas.set(x, y+1, `\__/`)
+	}
+
+	for _, h := range highlights {
+		if _, ok := g.Top(h); !ok {
+			outline(h, "  ")
+		}
+	}
+	for _, h := range g.Occupied() {
+		p, _ := g.Top(h)
+		ct, _ := hive.GetCreatureType(p.Creature())
+		outline(h, string([]byte{asciiColours[p.Colour()], ct.Letter}))
+	}
+
+	// Lower edges are marked last as they are also the upper edges of the hexes below
+	for _, h := range highlights {
+		x, y := asciiPosition(h)
+		canvas.set(x+1, y+1, "*")
+	}
+	for _, h := range g.Occupied() {
+		if height := len(g.Stack(h)); height > 1 {
+			mark := "+"
+			if height < 10 {
+				mark = strconv.Itoa(height)
+			}
+			x, y := asciiPosition(h)
+			canvas.set(x+2, y+1, mark)
+		}
+	}
+
+	if len(canvas) == 0 {
+		return ""
+	}
+	var minX, maxX, minY, maxY int
+	first := true
+	for pos := range canvas {
+		if first || pos[0] < minX {
+			minX = pos[0]
+		}
+		if first || pos[0] > maxX {
+			maxX = pos[0]
+		}
+		if first || pos[1] < minY {
+			minY = pos[1]
+		}
+		if first || pos[1] > maxY {
+			maxY = pos[1]
+		}
+		first = false
+	}
+
+	var sb strings.Builder
+	for y := minY; y <= maxY; y++ {
+		line := make([]byte, 0, maxX-minX+1)
+		for x := minX; x <= maxX; x++ {
+			c, ok := canvas[[2]int{x, y}]
+			if !ok {
+				c = ' '
+			}
+			line = append(line, c)
+		}
+		sb.WriteString(strings.TrimRight(string(line), " "))
+		sb.WriteByte('\n')
+	}
+	return sb.String()
+}
@@ -0,0 +1,122 @@
+package render
+
+import (
+	"testing"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// mustBuild builds a game from stacks of pieces listed bottom to top
+func mustBuild(t testing.TB, stacks map[hexgrid.Hex][]hive.Piece) *hive.Game {
+	t.Helper()
+	b := hive.NewBuilder(hive.Options{Expansions: []int{hive.Mosquito, hive.Ladybug, hive.Pillbug}})
+	for h, stack := range stacks {
+		for _, p := range stack {
+			b.Add(h, p)
+		}
+	}
+	g, err := b.Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	return g
+}
+
+func TestASCII(t *testing.T) {
+	// Game 1 of the hive package tests with colours
+	ring := map[hexgrid.Hex][]hive.Piece{
+		hexgrid.MustNew(0, 0, 0):  {hive.NewPiece(hive.QueenBee, hive.White, 1)},
+		hexgrid.MustNew(-1, 1, 0): {hive.NewPiece(hive.Beetle, hive.Black, 1)},
+		hexgrid.MustNew(-1, 0, 1): {hive.NewPiece(hive.Beetle, hive.Black, 2)},
+		hexgrid.MustNew(0, -1, 1): {hive.NewPiece(hive.Beetle, hive.White, 1)},
+		hexgrid.MustNew(1, -1, 0): {hive.NewPiece(hive.Beetle, hive.White, 2)},
+	}
+	stacked := map[hexgrid.Hex][]hive.Piece{
+		hexgrid.MustNew(0, 0, 0): {hive.NewPiece(hive.QueenBee, hive.White, 1)},
+		hexgrid.MustNew(1, -1, 0): {
+			hive.NewPiece(hive.QueenBee, hive.Black, 1),
+			hive.NewPiece(hive.Beetle, hive.Black, 1),
+		},
+	}
+
+	tests := map[string]struct {
+		stacks     map[hexgrid.Hex][]hive.Piece
+		highlights []hexgrid.Hex
+		want       string
+	}{
+		"Empty": {
+			want: "",
+		},
+		"Single piece": {
+			stacks: map[hexgrid.Hex][]hive.Piece{
+				hexgrid.MustNew(0, 0, 0): {hive.NewPiece(hive.Pillbug, hive.Black, 1)},
+			},
+			want: "" +
+				" __\n" +
+				"/bP\\\n" +
+				"\\__/\n",
+		},
+		"Ring": {
+			stacks: ring,
+			want: "" +
+				"    __\n" +
+				" __/wB\\__\n" +
+				"/bB\\__/wB\\\n" +
+				"\\__/wQ\\__/\n" +
+				"/bB\\__/\n" +
+				"\\__/\n",
+		},
+		"Ring highlights": {
+			stacks:     ring,
+			highlights: []hexgrid.Hex{hexgrid.MustNew(1, 0, -1), hexgrid.MustNew(0, 1, -1)},
+			want: "" +
+				"    __\n" +
+				" __/wB\\__\n" +
+				"/bB\\__/wB\\\n" +
+				"\\__/wQ\\__/\n" +
+				"/bB\\__/  \\\n" +
+				"\\__/  \\*_/\n" +
+				"   \\*_/\n",
+		},
+		"Stack": {
+			stacks:     stacked,
+			highlights: []hexgrid.Hex{hexgrid.MustNew(0, 0, 0)},
+			want: "" +
+				"    __\n" +
+				" __/bB\\\n" +
+				"/wQ\\_2/\n" +
+				"\\*_/\n",
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			g := mustBuild(t, test.stacks)
+			if got := ASCII(g, test.highlights...); got != test.want {
+				t.Errorf("Got\n%s\nwant\n%s", got, test.want)
+			}
+		})
+	}
+}
+
+func TestASCIIAvailableMoves(t *testing.T) {
+	g := mustBuild(t, map[hexgrid.Hex][]hive.Piece{
+		hexgrid.MustNew(0, 0, 0):  {hive.NewPiece(hive.QueenBee, hive.White, 1)},
+		hexgrid.MustNew(1, -1, 0): {hive.NewPiece(hive.Beetle, hive.Black, 1)},
+	})
+	moves, err := hive.GetAvailableMoves(hexgrid.MustNew(1, -1, 0), *g)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	want := "" +
+		" __\n" +
+		"/  \\__\n" +
+		"\\*_/bB\\\n" +
+		"/wQ\\__/\n" +
+		"\\*_/  \\\n" +
+		"   \\*_/\n"
+	if got := ASCII(g, moves...); got != want {
+		t.Errorf("Got\n%s\nwant\n%s", got, want)
+	}
+}
@@ -0,0 +1,72 @@
+package render
+
+import (
+	"image"
+	"image/gif"
+	"io"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// Default delays of replay frames in hundredths of a second
+const (
+	defaultGIFDelay      = 80
+	defaultGIFFinalDelay = 300
+)
+
+// GIFOptions holds the size and timing of an animated replay
+type GIFOptions struct {
+	Size       int // Distance from the centre of a hex to its corners in pixels, defaults to 24
+	Delay      int // Time each ply is shown in hundredths of a second, defaults to 80
+	FinalDelay int // Time the final position is shown before the replay loops, defaults to 300
+}
+
+// GIF writes an animated replay of the moves in a game's history with one frame per ply.
+// Every frame covers all the hexes used during the game so the board does not shift as the hive grows,
+// and the last move played is outlined. A game without history is drawn as a single frame.
+func GIF(w io.Writer, g *hive.Game, opts GIFOptions) error {
+	size := float64(opts.Size)
+	if size <= 0 {
+		size = defaultRasterHexSize
+	}
+	delay, finalDelay := opts.Delay, opts.FinalDelay
+	if delay <= 0 {
+		delay = defaultGIFDelay
+	}
+	if finalDelay <= 0 {
+		finalDelay = defaultGIFFinalDelay
+	}
+
+	// Replay the game first so the frame can be sized to fit every position
+	replay := g.Copy()
+	plies := len(replay.History())
+	if err := replay.GoTo(replay.MoveCount() - plies); err != nil {
+		return err
+	}
+	var positions []hive.Game
+	if plies == 0 {
+		positions = append(positions, replay.Copy())
+	}
+	for i := 0; i < plies; i++ {
+		if err := replay.Redo(); err != nil {
+			return err
+		}
+		positions = append(positions, replay.Copy())
+	}
+
+	var hexes []hexgrid.Hex
+	for i := range positions {
+		hexes = append(hexes, positions[i].Occupied()...)
+	}
+	frame := newRasterFrame(hexes, size)
+
+	anim := &gif.GIF{}
+	for i := range positions {
+		anim.Image = append(anim.Image, frame.draw(&positions[i], lastMoveHexes(&positions[i])))
+		anim.Delay = append(anim.Delay, delay)
+	}
+	anim.Delay[len(anim.Delay)-1] = finalDelay
+	anim.Config = image.Config{ColorModel: rasterPalette, Width: frame.width, Height: frame.height}
+	return gif.EncodeAll(w, anim)
+}
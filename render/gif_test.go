@@ -0,0 +1,105 @@
+package render
+
+import (
+	"bytes"
+	"image/gif"
+	"testing"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+func TestGIF(t *testing.T) {
+	g, err := hive.NewGame(hive.Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	moves := []hive.Move{
+		{Kind: hive.Placement, Creature: hive.Beetle, To: hexgrid.MustNew(0, 0, 0)},
+		{Kind: hive.Placement, Creature: hive.QueenBee, To: hexgrid.MustNew(0, -1, 1)},
+		{Kind: hive.Placement, Creature: hive.QueenBee, To: hexgrid.MustNew(1, 0, -1)},
+		{Kind: hive.Placement, Creature: hive.Spider, To: hexgrid.MustNew(0, -2, 2)},
+		{Kind: hive.Movement, From: hexgrid.MustNew(1, 0, -1), To: hexgrid.MustNew(1, -1, 0)},
+		{Kind: hive.Placement, Creature: hive.Spider, To: hexgrid.MustNew(-1, -1, 2)},
+		{Kind: hive.Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(0, -1, 1)},
+	}
+	for i, m := range moves {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	// An undone move is not part of the replay
+	undone := g.Copy()
+	if err := undone.Undo(); err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	empty, err := hive.NewGame(hive.Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	tests := map[string]struct {
+		game          *hive.Game
+		opts          GIFOptions
+		delays        []int
+		width, height int
+	}{
+		"Replay": {
+			game:   g,
+			delays: []int{80, 80, 80, 80, 80, 80, 300},
+			width:  144,
+			height: 176,
+		},
+		"Undone": {
+			game:   &undone,
+			opts:   GIFOptions{Delay: 50, FinalDelay: 100},
+			delays: []int{50, 50, 50, 50, 50, 100},
+			width:  144,
+			height: 176,
+		},
+		"No history": {
+			game:   empty,
+			opts:   GIFOptions{Size: 10},
+			delays: []int{300},
+			width:  50,
+			height: 50,
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := GIF(&buf, test.game, test.opts); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			anim, err := gif.DecodeAll(&buf)
+			if err != nil {
+				t.Fatalf("Invalid GIF: %v", err)
+			}
+			if len(anim.Image) != len(test.delays) {
+				t.Fatalf("Got %d frames, want %d", len(anim.Image), len(test.delays))
+			}
+			for i, delay := range test.delays {
+				if anim.Delay[i] != delay {
+					t.Errorf("Frame %d: got delay %d, want %d", i, anim.Delay[i], delay)
+				}
+				if b := anim.Image[i].Bounds(); b.Dx() != test.width || b.Dy() != test.height {
+					t.Errorf("Frame %d: got %dx%d, want %dx%d", i, b.Dx(), b.Dy(), test.width, test.height)
+				}
+			}
+			// Replays loop forever, while a single frame is written without a loop count
+			wantLoop := 0
+			if len(anim.Image) == 1 {
+				wantLoop = -1
+			}
+			if anim.LoopCount != wantLoop {
+				t.Errorf("Got loop count %d, want %d", anim.LoopCount, wantLoop)
+			}
+		})
+	}
+
+	// The game being replayed is left untouched
+	if got := len(g.History()); got != len(moves) {
+		t.Errorf("Got %d moves in history after replay, want %d", got, len(moves))
+	}
+}
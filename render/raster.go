@@ -0,0 +1,187 @@
+package render
+
+import (
+	"image"
+	"image/color"
+	"math"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// defaultRasterHexSize is the distance in pixels from the centre of a hex to its corners when none is given
+const defaultRasterHexSize = 24
+
+// Indexes of the colours in rasterPalette
+const (
+	rasterBackground = iota
+	rasterWhiteFill
+	rasterBlackFill
+	rasterOutline
+	rasterLastMove
+	rasterBadge
+)
+
+// rasterPalette holds every colour used in raster images, matching the SVG diagrams
+var rasterPalette = color.Palette{
+	rasterBackground: color.RGBA{0xfb, 0xf9, 0xf4, 0xff},
+	rasterWhiteFill:  color.RGBA{0xf4, 0xec, 0xd8, 0xff},
+	rasterBlackFill:  color.RGBA{0x2e, 0x2b, 0x28, 0xff},
+	rasterOutline:    color.RGBA{0x6b, 0x62, 0x57, 0xff},
+	rasterLastMove:   color.RGBA{0xe8, 0xa3, 0x17, 0xff},
+	rasterBadge:      color.RGBA{0xf2, 0xd1, 0x6b, 0xff},
+}
+
+// rasterFills and rasterInks are the tile and glyph colours for each player
+var (
+	rasterFills = map[int]uint8{hive.Black: rasterBlackFill, hive.White: rasterWhiteFill}
+	rasterInks  = map[int]uint8{hive.Black: rasterWhiteFill, hive.White: rasterBlackFill}
+)
+
+// RasterOptions holds the size and optional overlays of a raster image
+type RasterOptions struct {
+	Size     int  // Distance from the centre of a hex to its corners in pixels, defaults to 24
+	LastMove bool // Outline the origin and destination of the last move played
+}
+
+// Image draws a game as a paletted image.
+// Pieces are drawn as in SVG diagrams, with each tile in its player's colour showing its creature letter.
+func Image(g *hive.Game, opts RasterOptions) *image.Paletted {
+	size := float64(opts.Size)
+	if size <= 0 {
+		size = defaultRasterHexSize
+	}
+	var lastMove []hexgrid.Hex
+	if opts.LastMove {
+		lastMove = lastMoveHexes(g)
+	}
+	frame := newRasterFrame(append(g.Occupied(), lastMove...), size)
+	return frame.draw(g, lastMove)
+}
+
+// rasterFrame maps hexes to pixels in an image large enough for a fixed set of hexes
+type rasterFrame struct {
+	size             float64
+	offsetX, offsetY float64 // Pixel position of the centre of the origin hex
+	width, height    int
+}
+
+// newRasterFrame returns a frame covering the given hexes with a margin of half a hex
+func newRasterFrame(hexes []hexgrid.Hex, size float64) rasterFrame {
+	minX, minY, maxX, maxY := -size, -size, size, size
+	for i, h := range hexes {
+		x, y := hexCentre(h, size)
+		if i == 0 {
+			minX, minY, maxX, maxY = x, y, x, y
+		}
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	margin := size * 1.5
+	return rasterFrame{
+		size:    size,
+		offsetX: margin - minX,
+		offsetY: margin - minY,
+		width:   int(math.Ceil(maxX - minX + 2*margin)),
+		height:  int(math.Ceil(maxY - minY + 2*margin)),
+	}
+}
+
+// draw returns an image of a game with the given hexes outlined as the last move
+func (f rasterFrame) draw(g *hive.Game, lastMove []hexgrid.Hex) *image.Paletted {
+	img := image.NewPaletted(image.Rect(0, 0, f.width, f.height), rasterPalette)
+	size := f.size
+	scale := math.Max(1, math.Floor(size/10))
+
+	for _, h := range g.Occupied() {
+		x, y := hexCentre(h, size)
+		x, y = x+f.offsetX, y+f.offsetY
+		stack := g.Stack(h)
+		for i, p := range stack {
+			// Pieces lower in the stack are drawn further down and to the right
+			depth := float64(len(stack) - 1 - i)
+			px, py := x+depth*size*0.12, y+depth*size*0.12
+			fillHex(img, px, py, size*0.95, rasterOutline)
+			fillHex(img, px, py, size*0.95-1.5, rasterFills[p.Colour()])
+		}
+		top := stack[len(stack)-1]
+		ct, _ := hive.GetCreatureType(top.Creature())
+		drawGlyph(img, ct.Letter, x, y, scale, rasterInks[top.Colour()])
+
+		if len(stack) > 1 {
+			mark := byte('+')
+			if len(stack) < 10 {
+				mark = byte('0' + len(stack))
+			}
+			bx, by := x+size*0.5, y-size*0.55
+			fillDisc(img, bx, by, size*0.28, rasterOutline)
+			fillDisc(img, bx, by, size*0.28-1, rasterBadge)
+			drawGlyph(img, mark, bx, by, math.Max(1, math.Floor(scale/2)), rasterBlackFill)
+		}
+	}
+
+	for _, h := range lastMove {
+		x, y := hexCentre(h, size)
+		x, y = x+f.offsetX, y+f.offsetY
+		ringHex(img, x, y, size*0.95, size*0.12, rasterLastMove)
+	}
+	return img
+}
+
+// insideHex returns true if a point is inside a flat topped hex with its corners a distance size from its centre
+func insideHex(x, y, cx, cy, size float64) bool {
+	dx, dy := math.Abs(x-cx), math.Abs(y-cy)
+	return dy <= size*math.Sqrt(3)/2 && math.Sqrt(3)*dx+dy <= math.Sqrt(3)*size
+}
+
+// fillHex colours the pixels inside a hex
+func fillHex(img *image.Paletted, cx, cy, size float64, c uint8) {
+	ringHex(img, cx, cy, size, size, c)
+}
+
+// ringHex colours the pixels inside a hex that are within width of its edge
+func ringHex(img *image.Paletted, cx, cy, size, width float64, c uint8) {
+	for y := int(math.Floor(cy - size)); y <= int(math.Ceil(cy+size)); y++ {
+		for x := int(math.Floor(cx - size)); x <= int(math.Ceil(cx+size)); x++ {
+			px, py := float64(x)+0.5, float64(y)+0.5
+			if insideHex(px, py, cx, cy, size) && !insideHex(px, py, cx, cy, size-width) {
+				img.SetColorIndex(x, y, c)
+			}
+		}
+	}
+}
+
+// fillDisc colours the pixels inside a circle
+func fillDisc(img *image.Paletted, cx, cy, radius float64, c uint8) {
+	for y := int(math.Floor(cy - radius)); y <= int(math.Ceil(cy+radius)); y++ {
+		for x := int(math.Floor(cx - radius)); x <= int(math.Ceil(cx+radius)); x++ {
+			if math.Hypot(float64(x)+0.5-cx, float64(y)+0.5-cy) <= radius {
+				img.SetColorIndex(x, y, c)
+			}
+		}
+	}
+}
+
+// drawGlyph draws a character of the bitmap font centred on a point, with each font pixel scale pixels wide.
+// Characters missing from the font are not drawn.
+func drawGlyph(img *image.Paletted, ch byte, cx, cy, scale float64, c uint8) {
+	rows, ok := glyphs[ch]
+	if !ok {
+		return
+	}
+	left := int(math.Round(cx - glyphWidth*scale/2))
+	top := int(math.Round(cy - glyphHeight*scale/2))
+	s := int(scale)
+	for row, bits := range rows {
+		for col := 0; col < glyphWidth; col++ {
+			if bits&(1<<(glyphWidth-1-col)) == 0 {
+				continue
+			}
+			for y := 0; y < s; y++ {
+				for x := 0; x < s; x++ {
+					img.SetColorIndex(left+col*s+x, top+row*s+y, c)
+				}
+			}
+		}
+	}
+}
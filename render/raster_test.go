@@ -0,0 +1,110 @@
+package render
+
+import (
+	"image"
+	"testing"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// countColours returns the number of pixels of each palette index in an image
+func countColours(img *image.Paletted) map[uint8]int {
+	counts := map[uint8]int{}
+	for _, c := range img.Pix {
+		counts[c]++
+	}
+	return counts
+}
+
+func TestGlyphs(t *testing.T) {
+	for _, creature := range hive.GetCreatures() {
+		ct, _ := hive.GetCreatureType(creature)
+		if _, ok := glyphs[ct.Letter]; !ok {
+			t.Errorf("No glyph for creature letter %q", ct.Letter)
+		}
+	}
+	for ch := byte('0'); ch <= '9'; ch++ {
+		if _, ok := glyphs[ch]; !ok {
+			t.Errorf("No glyph for digit %q", ch)
+		}
+	}
+}
+
+func TestImage(t *testing.T) {
+	empty, err := hive.NewGame(hive.Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	//  __
+	// /bB\
+	// \2_/
+	single := mustBuild(t, map[hexgrid.Hex][]hive.Piece{
+		hexgrid.MustNew(2, 3, -5): {hive.NewPiece(hive.QueenBee, hive.White, 1), hive.NewPiece(hive.Beetle, hive.Black, 1)},
+	})
+	lastMoved, err := hive.NewBuilder(hive.Options{}).
+		Add(hexgrid.MustNew(0, 0, 0), hive.NewPiece(hive.QueenBee, hive.White, 1)).
+		SetLastMoved(hexgrid.MustNew(0, 0, 0)).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	tests := map[string]struct {
+		game          *hive.Game
+		opts          RasterOptions
+		width, height int
+		colours       []uint8
+		missing       []uint8
+	}{
+		"Empty": {
+			game:    empty,
+			width:   120,
+			height:  120,
+			colours: []uint8{rasterBackground},
+			missing: []uint8{rasterWhiteFill, rasterBlackFill, rasterOutline},
+		},
+		"Stack": {
+			game:    single,
+			width:   72,
+			height:  72,
+			colours: []uint8{rasterBackground, rasterWhiteFill, rasterBlackFill, rasterOutline, rasterBadge},
+			missing: []uint8{rasterLastMove},
+		},
+		"Size": {
+			game:    single,
+			opts:    RasterOptions{Size: 10},
+			width:   30,
+			height:  30,
+			colours: []uint8{rasterBackground, rasterWhiteFill, rasterBlackFill, rasterOutline, rasterBadge},
+		},
+		"Last moved without history": {
+			game:    lastMoved,
+			opts:    RasterOptions{LastMove: true},
+			width:   72,
+			height:  72,
+			colours: []uint8{rasterWhiteFill, rasterBlackFill, rasterLastMove},
+			missing: []uint8{rasterBadge},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			img := Image(test.game, test.opts)
+			if got := img.Bounds(); got != image.Rect(0, 0, test.width, test.height) {
+				t.Errorf("Got bounds %v, want %dx%d", got, test.width, test.height)
+			}
+			counts := countColours(img)
+			for _, c := range test.colours {
+				if counts[c] == 0 {
+					t.Errorf("No pixels of colour %d", c)
+				}
+			}
+			for _, c := range test.missing {
+				if counts[c] != 0 {
+					t.Errorf("Got %d pixels of colour %d, want none", counts[c], c)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+// Package render draws games for terminals, documents and sharing
+package render
+
+import (
+	"math"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// hexCentre returns the pixel position of the centre of a flat topped hex relative to the origin
+func hexCentre(h hexgrid.Hex, size float64) (float64, float64) {
+	return size * 1.5 * float64(h.Q()), size * math.Sqrt(3) * (float64(h.R()) + float64(h.Q())/2)
+}
+
+// lastMoveHexes returns the destination of the last move played and, for movements, its origin.
+// Without a history it falls back to the hex of the last moved piece.
+func lastMoveHexes(g *hive.Game) []hexgrid.Hex {
+	if history := g.History(); len(history) > 0 {
+		m := history[len(history)-1].Move
+		switch m.Kind {
+		case hive.Placement:
+			return []hexgrid.Hex{m.To}
+		case hive.Movement:
+			return []hexgrid.Hex{m.From, m.To}
+		}
+		return nil
+	}
+	if h, ok := g.LastMoved(); ok {
+		return []hexgrid.Hex{h}
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package render
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"math"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// defaultSVGHexSize is the distance in pixels from the centre of a hex to its corners when none is given
+const defaultSVGHexSize = 30
+
+// Colours used in SVG diagrams
+const (
+	svgWhiteFill = "#f4ecd8"
+	svgBlackFill = "#2e2b28"
+	svgWhiteInk  = "#2e2b28"
+	svgBlackInk  = "#f4ecd8"
+	svgOutline   = "#6b6257"
+	svgDot       = "#2f7bd9"
+	svgArrow     = "#d9412f"
+	svgLastMove  = "#e8a317"
+	svgBadge     = "#f2d16b"
+)
+
+// SVGOptions holds the size and optional overlays of an SVG diagram
+type SVGOptions struct {
+	Size     float64       // Distance from the centre of a hex to its corners in pixels, defaults to 30
+	Arrows   []hive.Move   // Movements drawn as arrows from origin to destination, other moves are ignored
+	Dots     []hexgrid.Hex // Hexes marked with a dot, such as the destinations from hive.GetAvailableMoves
+	LastMove bool          // Outline the origin and destination of the last move played
+}
+
+// svgFills and svgInks are the tile and glyph colours for each player
+var (
+	svgFills = map[int]string{hive.Black: svgBlackFill, hive.White: svgWhiteFill}
+	svgInks  = map[int]string{hive.Black: svgBlackInk, hive.White: svgWhiteInk}
+)
+
+// svgPoints returns the corners of a hex centred on a pixel position as an SVG points list
+func svgPoints(x, y, size float64) string {
+	var points string
+	for corner := 0; corner < 6; corner++ {
+		angle := math.Pi / 3 * float64(corner)
+		if corner > 0 {
+			points += " "
+		}
+		points += fmt.Sprintf("%.1f,%.1f", x+size*math.Cos(angle), y+size*math.Sin(angle))
+	}
+	return points
+}
+
+// SVG writes a game as an SVG image.
+// Each piece is a tile in its player's colour showing its creature letter. Stacks show the pieces beneath
+// peeking out below the top piece and a badge with the height of the stack.
+func SVG(w io.Writer, g *hive.Game, opts SVGOptions) error {
+	size := opts.Size
+	if size <= 0 {
+		size = defaultSVGHexSize
+	}
+
+	var lastMove []hexgrid.Hex
+	if opts.LastMove {
+		lastMove = lastMoveHexes(g)
+	}
+	var arrows []hive.Move
+	for _, m := range opts.Arrows {
+		if m.Kind == hive.Movement {
+			arrows = append(arrows, m)
+		}
+	}
+
+	// Frame every hex that is drawn with a margin of half a hex
+	hexes := append(append([]hexgrid.Hex{}, g.Occupied()...), opts.Dots...)
+	hexes = append(hexes, lastMove...)
+	for _, m := range arrows {
+		hexes = append(hexes, m.From, m.To)
+	}
+	minX, minY, maxX, maxY := -size, -size, size, size
+	for i, h := range hexes {
+		x, y := hexCentre(h, size)
+		if i == 0 {
+			minX, minY, maxX, maxY = x, y, x, y
+		}
+		minX, maxX = math.Min(minX, x), math.Max(maxX, x)
+		minY, maxY = math.Min(minY, y), math.Max(maxY, y)
+	}
+	margin := size * 1.5
+	minX, minY, maxX, maxY = minX-margin, minY-margin, maxX+margin, maxY+margin
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="%.1f %.1f %.1f %.1f">`+"\n",
+		math.Ceil(maxX-minX), math.Ceil(maxY-minY), minX, minY, maxX-minX, maxY-minY)
+	fmt.Fprintf(bw, `<defs><marker id="arrowhead" viewBox="0 0 10 10" refX="5" refY="5" markerWidth="4" markerHeight="4" orient="auto"><path d="M0,0 L10,5 L0,10 z" fill="%s"/></marker></defs>`+"\n", svgArrow)
+
+	tileSize := size * 0.95
+	offset := size * 0.12
+	for _, h := range g.Occupied() {
+		x, y := hexCentre(h, size)
+		stack := g.Stack(h)
+		fmt.Fprintf(bw, `<g class="stack" data-height="%d">`+"\n", len(stack))
+		for i, p := range stack {
+			// Pieces lower in the stack are drawn further down and to the right
+			depth := float64(len(stack) - 1 - i)
+			px, py := x+depth*offset, y+depth*offset
+			fmt.Fprintf(bw, `<polygon class="tile" points="%s" fill="%s" stroke="%s" stroke-width="1.5"><title>`, svgPoints(px, py, tileSize), svgFills[p.Colour()], svgOutline)
+			xml.EscapeText(bw, []byte(hive.FormatPiece(p)))
+			fmt.Fprint(bw, "</title></polygon>\n")
+		}
+		top := stack[len(stack)-1]
+		ct, _ := hive.GetCreatureType(top.Creature())
+		fmt.Fprintf(bw, `<text class="glyph" x="%.1f" y="%.1f" font-family="sans-serif" font-size="%.1f" font-weight="bold" text-anchor="middle" dominant-baseline="central" fill="%s">%c</text>`+"\n",
+			x, y, size*0.8, svgInks[top.Colour()], ct.Letter)
+		if len(stack) > 1 {
+			bx, by := x+size*0.5, y-size*0.55
+			fmt.Fprintf(bw, `<circle class="height" cx="%.1f" cy="%.1f" r="%.1f" fill="%s" stroke="%s"/>`+"\n", bx, by, size*0.25, svgBadge, svgOutline)
+			fmt.Fprintf(bw, `<text x="%.1f" y="%.1f" font-family="sans-serif" font-size="%.1f" text-anchor="middle" dominant-baseline="central" fill="%s">%d</text>`+"\n",
+				bx, by, size*0.35, svgWhiteInk, len(stack))
+		}
+		fmt.Fprint(bw, "</g>\n")
+	}
+
+	for _, h := range lastMove {
+		x, y := hexCentre(h, size)
+		fmt.Fprintf(bw, `<polygon class="last-move" points="%s" fill="none" stroke="%s" stroke-width="%.1f"/>`+"\n", svgPoints(x, y, size*0.9), svgLastMove, size*0.12)
+	}
+	for _, h := range opts.Dots {
+		x, y := hexCentre(h, size)
+		fmt.Fprintf(bw, `<circle class="dot" cx="%.1f" cy="%.1f" r="%.1f" fill="%s" fill-opacity="0.8"/>`+"\n", x, y, size*0.2, svgDot)
+	}
+	for _, m := range arrows {
+		x1, y1 := hexCentre(m.From, size)
+		x2, y2 := hexCentre(m.To, size)
+		// Stop short of the destination's centre so the arrowhead does not cover a glyph there
+		if length := math.Hypot(x2-x1, y2-y1); length > 0 {
+			shorten := math.Min(size*0.4, length/2)
+			x2, y2 = x2-(x2-x1)*shorten/length, y2-(y2-y1)*shorten/length
+		}
+		fmt.Fprintf(bw, `<line class="arrow" x1="%.1f" y1="%.1f" x2="%.1f" y2="%.1f" stroke="%s" stroke-width="%.1f" stroke-linecap="round" marker-end="url(#arrowhead)"/>`+"\n",
+			x1, y1, x2, y2, svgArrow, size*0.12)
+	}
+
+	fmt.Fprint(bw, "</svg>\n")
+	return bw.Flush()
+}
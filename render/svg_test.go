@@ -0,0 +1,154 @@
+package render
+
+import (
+	"bytes"
+	"encoding/xml"
+	"io"
+	"reflect"
+	"testing"
+
+	"github.com/maze-mapper/hive"
+	"github.com/maze-mapper/hive/hexgrid"
+)
+
+// svgSummary counts the elements of each class in an SVG document and records the size of the image
+type svgSummary struct {
+	width, height string
+	classes       map[string]int
+}
+
+// summariseSVG parses an SVG document, failing the test if it is not well formed
+func summariseSVG(t *testing.T, data []byte) svgSummary {
+	t.Helper()
+	summary := svgSummary{classes: map[string]int{}}
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	for {
+		token, err := decoder.Token()
+		if err == io.EOF {
+			return summary
+		}
+		if err != nil {
+			t.Fatalf("Invalid SVG: %v\n%s", err, data)
+		}
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		for _, attr := range start.Attr {
+			switch {
+			case start.Name.Local == "svg" && attr.Name.Local == "width":
+				summary.width = attr.Value
+			case start.Name.Local == "svg" && attr.Name.Local == "height":
+				summary.height = attr.Value
+			case attr.Name.Local == "class":
+				summary.classes[attr.Value]++
+			}
+		}
+	}
+}
+
+func TestSVG(t *testing.T) {
+	g, err := hive.NewGame(hive.Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	for i, m := range []hive.Move{
+		{Kind: hive.Placement, Creature: hive.Beetle, To: hexgrid.MustNew(0, 0, 0)},
+		{Kind: hive.Placement, Creature: hive.QueenBee, To: hexgrid.MustNew(0, -1, 1)},
+		{Kind: hive.Placement, Creature: hive.QueenBee, To: hexgrid.MustNew(1, 0, -1)},
+		{Kind: hive.Placement, Creature: hive.Spider, To: hexgrid.MustNew(0, -2, 2)},
+		{Kind: hive.Movement, From: hexgrid.MustNew(1, 0, -1), To: hexgrid.MustNew(1, -1, 0)},
+		{Kind: hive.Placement, Creature: hive.Spider, To: hexgrid.MustNew(-1, -1, 2)},
+		{Kind: hive.Movement, From: hexgrid.MustNew(0, 0, 0), To: hexgrid.MustNew(0, -1, 1)},
+	} {
+		if err := g.Play(m); err != nil {
+			t.Fatalf("Move %d: unexpected error %v", i, err)
+		}
+	}
+	queenMoves, err := hive.GetAvailableMoves(hexgrid.MustNew(1, -1, 0), *g)
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	single, err := hive.NewBuilder(hive.Options{}).
+		Add(hexgrid.MustNew(2, 3, -5), hive.NewPiece(hive.QueenBee, hive.White, 1)).
+		SetLastMoved(hexgrid.MustNew(2, 3, -5)).
+		Build()
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+	empty, err := hive.NewGame(hive.Options{})
+	if err != nil {
+		t.Fatalf("Unexpected error %v", err)
+	}
+
+	tests := map[string]struct {
+		game          *hive.Game
+		opts          SVGOptions
+		width, height string
+		classes       map[string]int
+	}{
+		"Empty": {
+			game:    empty,
+			width:   "150",
+			height:  "150",
+			classes: map[string]int{},
+		},
+		"Single piece": {
+			game:    single,
+			width:   "90",
+			height:  "90",
+			classes: map[string]int{"stack": 1, "tile": 1, "glyph": 1},
+		},
+		"Size": {
+			game:    single,
+			opts:    SVGOptions{Size: 10},
+			width:   "30",
+			height:  "30",
+			classes: map[string]int{"stack": 1, "tile": 1, "glyph": 1},
+		},
+		"Last moved without history": {
+			game:    single,
+			opts:    SVGOptions{LastMove: true},
+			width:   "90",
+			height:  "90",
+			classes: map[string]int{"stack": 1, "tile": 1, "glyph": 1, "last-move": 1},
+		},
+		"Stack": {
+			game:    g,
+			width:   "180",
+			height:  "168",
+			classes: map[string]int{"stack": 4, "tile": 5, "glyph": 4, "height": 1},
+		},
+		"Overlays": {
+			game: g,
+			opts: SVGOptions{
+				Dots: queenMoves,
+				Arrows: []hive.Move{
+					{Kind: hive.Movement, From: hexgrid.MustNew(1, -1, 0), To: queenMoves[0]},
+					{Kind: hive.Placement, Creature: hive.Grasshopper, To: hexgrid.MustNew(2, -1, -1)},
+				},
+				LastMove: true,
+			},
+			width:   "180",
+			height:  "194",
+			classes: map[string]int{"stack": 4, "tile": 5, "glyph": 4, "height": 1, "last-move": 2, "dot": len(queenMoves), "arrow": 1},
+		},
+	}
+
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := SVG(&buf, test.game, test.opts); err != nil {
+				t.Fatalf("Unexpected error %v", err)
+			}
+			got := summariseSVG(t, buf.Bytes())
+			if got.width != test.width || got.height != test.height {
+				t.Errorf("Got size %sx%s, want %sx%s", got.width, got.height, test.width, test.height)
+			}
+			if !reflect.DeepEqual(got.classes, test.classes) {
+				t.Errorf("Got elements %v, want %v", got.classes, test.classes)
+			}
+		})
+	}
+}